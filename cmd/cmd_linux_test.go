@@ -3,6 +3,7 @@
 package cmd_test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -37,3 +38,23 @@ func Test_RunE_Linux(test *testing.T) {
 	err = cmd.RunE(cmd.RootCmd, []string{})
 	require.NoError(test, err)
 }
+
+func Test_StatsCmd_Linux(test *testing.T) {
+	inputFile, err := os.CreateTemp(test.TempDir(), "move-cmd-stats-*.jsonl")
+	require.NoError(test, err)
+
+	_, err = inputFile.WriteString(`{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}` + "\n" + `{"DATA_SOURCE": "TEST"}` + "\n")
+	require.NoError(test, err)
+	require.NoError(test, inputFile.Close())
+
+	test.Setenv("SENZING_TOOLS_INPUT_URL", "file://"+inputFile.Name())
+	test.Setenv("SENZING_TOOLS_STATS_FORMAT", "json")
+
+	outbuf := bytes.NewBufferString("")
+	cmd.StatsCmd.SetOut(outbuf)
+	cmd.StatsCmd.PreRun(cmd.StatsCmd, []string{})
+
+	err = cmd.StatsCmd.RunE(cmd.StatsCmd, []string{})
+	require.NoError(test, err)
+	require.Contains(test, outbuf.String(), `"missingRecordId": 1`)
+}
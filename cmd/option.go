@@ -0,0 +1,523 @@
+package cmd
+
+import (
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
+)
+
+// ----------------------------------------------------------------------------
+// Context variables specific to move
+// ----------------------------------------------------------------------------
+
+var AgeIdentityFile = option.ContextVariable{
+	Arg:     "age-identity-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AGE_IDENTITY_FILE", ""),
+	Envar:   "SENZING_TOOLS_AGE_IDENTITY_FILE",
+	Help:    "Path of a file of age private keys that decrypt inputs whose names end in .age [%s]",
+	Type:    optiontype.String,
+}
+
+var AgeRecipient = option.ContextVariable{
+	Arg:     "age-recipient",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_AGE_RECIPIENT",
+	Help:    "age public key, age1..., to encrypt file outputs to; may be given more than once [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var AgeRecipientsFile = option.ContextVariable{
+	Arg:     "age-recipients-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AGE_RECIPIENTS_FILE", ""),
+	Envar:   "SENZING_TOOLS_AGE_RECIPIENTS_FILE",
+	Help:    "Path of a file of age public keys, one per line, to encrypt file outputs to [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPCACertFile = option.ContextVariable{
+	Arg:     "amqp-ca-cert-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_CA_CERT_FILE", ""),
+	Envar:   "SENZING_TOOLS_AMQP_CA_CERT_FILE",
+	Help:    "PEM file of CA certificates trusted, in addition to the system's, for an amqps output URL [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPClientCertFile = option.ContextVariable{
+	Arg:     "amqp-client-cert-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_CLIENT_CERT_FILE", ""),
+	Envar:   "SENZING_TOOLS_AMQP_CLIENT_CERT_FILE",
+	Help:    "PEM file of the client certificate for an amqps output URL, for mutual TLS [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPClientKeyFile = option.ContextVariable{
+	Arg:     "amqp-client-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_CLIENT_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_AMQP_CLIENT_KEY_FILE",
+	Help:    "PEM file of the key of the client certificate for an amqps output URL [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPConfirm = option.ContextVariable{
+	Arg:     "amqp-confirm",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_AMQP_CONFIRM", false),
+	Envar:   "SENZING_TOOLS_AMQP_CONFIRM",
+	Help:    "Wait for the broker to confirm each record, publishing again those not confirmed when the connection fails; always on for amqps output URLs [%s]",
+	Type:    optiontype.Bool,
+}
+
+var AMQPDeclare = option.ContextVariable{
+	Arg:     "amqp-declare",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_AMQP_DECLARE", true),
+	Envar:   "SENZING_TOOLS_AMQP_DECLARE",
+	Help:    "Declare the AMQP exchange and queue and bind them when starting; turn off to publish to existing ones [%s]",
+	Type:    optiontype.Bool,
+}
+
+var AMQPDurable = option.ContextVariable{
+	Arg:     "amqp-durable",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_AMQP_DURABLE", false),
+	Envar:   "SENZING_TOOLS_AMQP_DURABLE",
+	Help:    "Declare the AMQP exchange and queue durable, surviving a broker restart [%s]",
+	Type:    optiontype.Bool,
+}
+
+var AMQPExchange = option.ContextVariable{
+	Arg:     "amqp-exchange",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_EXCHANGE", ""),
+	Envar:   "SENZING_TOOLS_AMQP_EXCHANGE",
+	Help:    "AMQP exchange to publish to, instead of the exchange query parameter of the output URL [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPExchangeType = option.ContextVariable{
+	Arg:     "amqp-exchange-type",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_EXCHANGE_TYPE", "direct"),
+	Envar:   "SENZING_TOOLS_AMQP_EXCHANGE_TYPE",
+	Help:    "Type of the AMQP exchange declared: direct, fanout, headers or topic [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPHeaders = option.ContextVariable{
+	Arg:     "amqp-headers",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_AMQP_HEADERS", false),
+	Envar:   "SENZING_TOOLS_AMQP_HEADERS",
+	Help:    "Add the input and line of each record as the source and line AMQP message headers [%s]",
+	Type:    optiontype.Bool,
+}
+
+var AMQPPassword = option.ContextVariable{
+	Arg:     "amqp-password",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_PASSWORD", ""),
+	Envar:   "SENZING_TOOLS_AMQP_PASSWORD",
+	Help:    "Password for an amqp(s) output URL, replacing any in the URL [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPPasswordFile = option.ContextVariable{
+	Arg:     "amqp-password-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_PASSWORD_FILE", ""),
+	Envar:   "SENZING_TOOLS_AMQP_PASSWORD_FILE",
+	Help:    "File holding the password for an amqp(s) output URL, replacing any in the URL [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPPersistent = option.ContextVariable{
+	Arg:     "amqp-persistent",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_AMQP_PERSISTENT", true),
+	Envar:   "SENZING_TOOLS_AMQP_PERSISTENT",
+	Help:    "Publish AMQP messages as persistent, written to disk by the broker [%s]",
+	Type:    optiontype.Bool,
+}
+
+var AMQPQueue = option.ContextVariable{
+	Arg:     "amqp-queue",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_QUEUE", ""),
+	Envar:   "SENZING_TOOLS_AMQP_QUEUE",
+	Help:    "AMQP queue to declare and bind, instead of the queue-name query parameter of the output URL [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPRoutingKey = option.ContextVariable{
+	Arg:     "amqp-routing-key",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_ROUTING_KEY", ""),
+	Envar:   "SENZING_TOOLS_AMQP_ROUTING_KEY",
+	Help:    "AMQP routing key, instead of the routing-key query parameter of the output URL; {NAME} is replaced by the record attribute NAME, such as {DATA_SOURCE} [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPServerName = option.ContextVariable{
+	Arg:     "amqp-server-name",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_SERVER_NAME", ""),
+	Envar:   "SENZING_TOOLS_AMQP_SERVER_NAME",
+	Help:    "Name the broker's certificate is verified against for an amqps output URL, instead of the URL's host [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPTLSMinVersion = option.ContextVariable{
+	Arg:     "amqp-tls-min-version",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_TLS_MIN_VERSION", "1.2"),
+	Envar:   "SENZING_TOOLS_AMQP_TLS_MIN_VERSION",
+	Help:    "Minimum TLS version for an amqps output URL: 1.2 or 1.3 [%s]",
+	Type:    optiontype.String,
+}
+
+var AMQPUsername = option.ContextVariable{
+	Arg:     "amqp-username",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AMQP_USERNAME", ""),
+	Envar:   "SENZING_TOOLS_AMQP_USERNAME",
+	Help:    "User for an amqp(s) output URL, replacing any in the URL [%s]",
+	Type:    optiontype.String,
+}
+
+var AuditManifest = option.ContextVariable{
+	Arg:     "audit-manifest",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUDIT_MANIFEST", ""),
+	Envar:   "SENZING_TOOLS_AUDIT_MANIFEST",
+	Help:    "Path of a JSON file written at the end of the run describing its inputs, outputs, counts and configuration [%s]",
+	Type:    optiontype.String,
+}
+
+var Dedupe = option.ContextVariable{
+	Arg:     "dedupe",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_DEDUPE", "off"),
+	Envar:   "SENZING_TOOLS_DEDUPE",
+	Help:    "Duplicate record detection; off, drop or report [%s]",
+	Type:    optiontype.String,
+}
+
+var DedupeDivertFile = option.ContextVariable{
+	Arg:     "dedupe-divert-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_DEDUPE_DIVERT_FILE", ""),
+	Envar:   "SENZING_TOOLS_DEDUPE_DIVERT_FILE",
+	Help:    "Path of a JSONL file that receives the duplicates dropped by --dedupe=drop [%s]",
+	Type:    optiontype.String,
+}
+
+var DedupeKey = option.ContextVariable{
+	Arg:     "dedupe-key",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_DEDUPE_KEY", "record-key"),
+	Envar:   "SENZING_TOOLS_DEDUPE_KEY",
+	Help:    "What makes records duplicates; record-key (DATA_SOURCE and RECORD_ID) or content-hash [%s]",
+	Type:    optiontype.String,
+}
+
+var DefaultDataSource = option.ContextVariable{
+	Arg:     "default-data-source",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_DEFAULT_DATA_SOURCE",
+	Help:    "DATA_SOURCE given to records that have none; NAME for every input or INPUT=NAME for a single input [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var Filter = option.ContextVariable{
+	Arg:     "filter",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_FILTER", ""),
+	Envar:   "SENZING_TOOLS_FILTER",
+	Help:    "Only move records matching an expression, e.g. 'DATA_SOURCE == \"CUSTOMERS\" && has(EMAIL_ADDRESS)' [%s]",
+	Type:    optiontype.String,
+}
+
+var Follow = option.ContextVariable{
+	Arg:     "follow",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_FOLLOW", false),
+	Envar:   "SENZING_TOOLS_FOLLOW",
+	Help:    "Keep reading lines appended to file:// JSONL inputs, like tail -F, until interrupted [%s]",
+	Type:    optiontype.Bool,
+}
+
+var FollowStateDirectory = option.ContextVariable{
+	Arg:     "follow-state-directory",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_FOLLOW_STATE_DIRECTORY", ""),
+	Envar:   "SENZING_TOOLS_FOLLOW_STATE_DIRECTORY",
+	Help:    "Directory where the position read in followed inputs is saved; defaults to the directory of each input [%s]",
+	Type:    optiontype.String,
+}
+
+var GenerateRecordID = option.ContextVariable{
+	Arg:     "generate-record-id",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_GENERATE_RECORD_ID", ""),
+	Envar:   "SENZING_TOOLS_GENERATE_RECORD_ID",
+	Help:    "Generate a RECORD_ID for records that have none; hash, sequence or uuid [%s]",
+	Type:    optiontype.String,
+}
+
+var HTTPBasicAuthPassword = option.ContextVariable{
+	Arg:     "http-basic-auth-password",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_BASIC_AUTH_PASSWORD", ""),
+	Envar:   "SENZING_TOOLS_HTTP_BASIC_AUTH_PASSWORD",
+	Help:    "Password for HTTP basic authentication of http(s) inputs [%s]",
+	Type:    optiontype.String,
+}
+
+var HTTPBasicAuthUser = option.ContextVariable{
+	Arg:     "http-basic-auth-user",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_BASIC_AUTH_USER", ""),
+	Envar:   "SENZING_TOOLS_HTTP_BASIC_AUTH_USER",
+	Help:    "User for HTTP basic authentication of http(s) inputs [%s]",
+	Type:    optiontype.String,
+}
+
+var HTTPBearerToken = option.ContextVariable{
+	Arg:     "http-bearer-token",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_BEARER_TOKEN", ""),
+	Envar:   "SENZING_TOOLS_HTTP_BEARER_TOKEN",
+	Help:    "Bearer token sent with requests for http(s) inputs [%s]",
+	Type:    optiontype.String,
+}
+
+var HTTPBearerTokenFile = option.ContextVariable{
+	Arg:     "http-bearer-token-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_BEARER_TOKEN_FILE", ""),
+	Envar:   "SENZING_TOOLS_HTTP_BEARER_TOKEN_FILE",
+	Help:    "File holding the bearer token sent with requests for http(s) inputs; read for every request [%s]",
+	Type:    optiontype.String,
+}
+
+var HTTPCACertFile = option.ContextVariable{
+	Arg:     "http-ca-cert-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_CA_CERT_FILE", ""),
+	Envar:   "SENZING_TOOLS_HTTP_CA_CERT_FILE",
+	Help:    "PEM file of certificate authorities trusted for https inputs, in addition to the system's [%s]",
+	Type:    optiontype.String,
+}
+
+var HTTPClientCertFile = option.ContextVariable{
+	Arg:     "http-client-cert-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_CLIENT_CERT_FILE", ""),
+	Envar:   "SENZING_TOOLS_HTTP_CLIENT_CERT_FILE",
+	Help:    "PEM client certificate presented to https inputs [%s]",
+	Type:    optiontype.String,
+}
+
+var HTTPClientKeyFile = option.ContextVariable{
+	Arg:     "http-client-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_CLIENT_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_HTTP_CLIENT_KEY_FILE",
+	Help:    "PEM private key of --http-client-cert-file [%s]",
+	Type:    optiontype.String,
+}
+
+var HTTPHeader = option.ContextVariable{
+	Arg:     "http-header",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_HTTP_HEADER",
+	Help:    "Header sent with requests for http(s) inputs, as \"Name: value\"; may be repeated [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var HTTPProxy = option.ContextVariable{
+	Arg:     "http-proxy",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_PROXY", ""),
+	Envar:   "SENZING_TOOLS_HTTP_PROXY",
+	Help:    "Proxy URL for http(s) inputs; defaults to HTTP_PROXY, HTTPS_PROXY and NO_PROXY [%s]",
+	Type:    optiontype.String,
+}
+
+var HTTPReadTimeout = option.ContextVariable{
+	Arg:     "http-read-timeout-in-seconds",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_HTTP_READ_TIMEOUT_IN_SECONDS", 60),
+	Envar:   "SENZING_TOOLS_HTTP_READ_TIMEOUT_IN_SECONDS",
+	Help:    "Seconds an http(s) input may send no data before it is requested again; 0 waits forever [%s]",
+	Type:    optiontype.Int,
+}
+
+var HTTPRetries = option.ContextVariable{
+	Arg:     "http-retries",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_HTTP_RETRIES", 5),
+	Envar:   "SENZING_TOOLS_HTTP_RETRIES",
+	Help:    "Times a failed http(s) input request is retried, with backoff, before the input fails [%s]",
+	Type:    optiontype.Int,
+}
+
+var HTTPTimeout = option.ContextVariable{
+	Arg:     "http-timeout-in-seconds",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_HTTP_TIMEOUT_IN_SECONDS", 30),
+	Envar:   "SENZING_TOOLS_HTTP_TIMEOUT_IN_SECONDS",
+	Help:    "Seconds to wait for an http(s) input to connect and respond [%s]",
+	Type:    optiontype.Int,
+}
+
+var InputChecksumURL = option.ContextVariable{
+	Arg:     "input-checksum-url",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_INPUT_CHECKSUM_URL", ""),
+	Envar:   "SENZING_TOOLS_INPUT_CHECKSUM_URL",
+	Help:    "URL of a checksum file, as written by sha256sum, to verify inputs against; {INPUT} is replaced by the input URL [%s]",
+	Type:    optiontype.String,
+}
+
+var InputParallelism = option.ContextVariable{
+	Arg:     "input-parallelism",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_INPUT_PARALLELISM", 1),
+	Envar:   "SENZING_TOOLS_INPUT_PARALLELISM",
+	Help:    "Number of inputs read at the same time [%s]",
+	Type:    optiontype.Int,
+}
+
+var InputRecursive = option.ContextVariable{
+	Arg:     "input-recursive",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_INPUT_RECURSIVE", false),
+	Envar:   "SENZING_TOOLS_INPUT_RECURSIVE",
+	Help:    "Also read the files in subdirectories of an input directory [%s]",
+	Type:    optiontype.Bool,
+}
+
+// InputURL replaces option.InputURL so that it can be given more than once.
+var InputSHA256 = option.ContextVariable{
+	Arg:     "input-sha256",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_INPUT_SHA256",
+	Help:    "SHA-256 in hex the bytes of inputs must have; SHA256 for every input or INPUT=SHA256 for a single input [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var InputURL = option.ContextVariable{
+	Arg:     "input-url",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_INPUT_URL",
+	Help:    "Input URL used for processing; a URL, a file:// glob pattern or a file:// directory, may be repeated [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var MessageID = option.ContextVariable{
+	Arg:     "message-id",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_MESSAGE_ID", "source-line"),
+	Envar:   "SENZING_TOOLS_MESSAGE_ID",
+	Help:    "How queue message IDs are formed; source-line, record-key (DATA_SOURCE|RECORD_ID) or content-hash [%s]",
+	Type:    optiontype.String,
+}
+
+var Operation = option.ContextVariable{
+	Arg:     "operation",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_OPERATION",
+	Help:    "Operation (add, replace or delete) of records without an operation attribute; OPERATION for every input or INPUT=OPERATION for a single input [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var OperationAttribute = option.ContextVariable{
+	Arg:     "operation-attribute",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_OPERATION_ATTRIBUTE", ""),
+	Envar:   "SENZING_TOOLS_OPERATION_ATTRIBUTE",
+	Help:    "Record attribute holding the operation (add, replace or delete) of the record [%s]",
+	Type:    optiontype.String,
+}
+
+var OutputEnvelope = option.ContextVariable{
+	Arg:     "output-envelope",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_OUTPUT_ENVELOPE", "none"),
+	Envar:   "SENZING_TOOLS_OUTPUT_ENVELOPE",
+	Help:    "Format of records written: none for the records as read or json to wrap each record with its source, line, ingest time, hash and run ID [%s]",
+	Type:    optiontype.String,
+}
+
+var OutputURLFile = option.ContextVariable{
+	Arg:     "output-url-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_OUTPUT_URL_FILE", ""),
+	Envar:   "SENZING_TOOLS_OUTPUT_URL_FILE",
+	Help:    "File holding the output URL, instead of --output-url [%s]",
+	Type:    optiontype.String,
+}
+
+var RedactDrop = option.ContextVariable{
+	Arg:     "redact-drop",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_REDACT_DROP",
+	Help:    "Attributes removed from every record, e.g. SSN_NUMBER [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var RedactHash = option.ContextVariable{
+	Arg:     "redact-hash",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_REDACT_HASH",
+	Help:    "Attributes replaced by an HMAC-SHA256 of their value using --redact-key, e.g. DATE_OF_BIRTH [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var RedactKey = option.ContextVariable{
+	Arg:     "redact-key",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_REDACT_KEY", ""),
+	Envar:   "SENZING_TOOLS_REDACT_KEY",
+	Help:    "Secret key for --redact-hash [%s]",
+	Type:    optiontype.String,
+}
+
+var RedactMask = option.ContextVariable{
+	Arg:     "redact-mask",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_REDACT_MASK",
+	Help:    "Attributes whose letters and digits are masked, except the last four, e.g. PHONE_NUMBER [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var SetDataSource = option.ContextVariable{
+	Arg:     "set-data-source",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_SET_DATA_SOURCE",
+	Help:    "DATA_SOURCE that replaces the DATA_SOURCE of every record; NAME for every input or INPUT=NAME for a single input [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var SQSMessageGroupID = option.ContextVariable{
+	Arg:     "sqs-message-group-id",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_SQS_MESSAGE_GROUP_ID", "{DATA_SOURCE}"),
+	Envar:   "SENZING_TOOLS_SQS_MESSAGE_GROUP_ID",
+	Help:    "Message group ID of records sent to an SQS FIFO queue; {NAME} is replaced by the record attribute NAME [%s]",
+	Type:    optiontype.String,
+}
+
+var StatsFormat = option.ContextVariable{
+	Arg:     "stats-format",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_STATS_FORMAT", "table"),
+	Envar:   "SENZING_TOOLS_STATS_FORMAT",
+	Help:    "Format of the stats report; table or json [%s]",
+	Type:    optiontype.String,
+}
+
+var StopOnInputError = option.ContextVariable{
+	Arg:     "stop-on-input-error",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_STOP_ON_INPUT_ERROR", false),
+	Envar:   "SENZING_TOOLS_STOP_ON_INPUT_ERROR",
+	Help:    "Stop reading inputs after the first input that fails [%s]",
+	Type:    optiontype.Bool,
+}
+
+var TransformFile = option.ContextVariable{
+	Arg:     "transform-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TRANSFORM_FILE", ""),
+	Envar:   "SENZING_TOOLS_TRANSFORM_FILE",
+	Help:    "YAML or JSON file of field mapping rules applied to each record before validation [%s]",
+	Type:    optiontype.String,
+}
+
+var ValidationLevel = option.ContextVariable{
+	Arg:     "validation-level",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_VALIDATION_LEVEL", "basic"),
+	Envar:   "SENZING_TOOLS_VALIDATION_LEVEL",
+	Help:    "Record validation; basic checks DATA_SOURCE and RECORD_ID, strict also checks attributes against the Generic Entity Specification [%s]",
+	Type:    optiontype.String,
+}
+
+var WatchDirectory = option.ContextVariable{
+	Arg:     "watch-directory",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_WATCH_DIRECTORY", ""),
+	Envar:   "SENZING_TOOLS_WATCH_DIRECTORY",
+	Help:    "Directory monitored for files to move [%s]",
+	Type:    optiontype.String,
+}
+
+var WatchManifest = option.ContextVariable{
+	Arg:     "watch-manifest",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_WATCH_MANIFEST", ""),
+	Envar:   "SENZING_TOOLS_WATCH_MANIFEST",
+	Help:    "File the result of each moved file is appended to; defaults to manifest.jsonl in the watch directory [%s]",
+	Type:    optiontype.String,
+}
+
+var WatchRequireMarker = option.ContextVariable{
+	Arg:     "watch-require-marker",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_WATCH_REQUIRE_MARKER", false),
+	Envar:   "SENZING_TOOLS_WATCH_REQUIRE_MARKER",
+	Help:    "Only move a file once a marker file of the same name ending in .done is created [%s]",
+	Type:    optiontype.Bool,
+}
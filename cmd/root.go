@@ -4,8 +4,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
@@ -30,22 +34,91 @@ const (
 `
 )
 
+var errForPackage = errors.New("cmd")
+
+// Parameters whose values are never shown.
+var secretKeys = map[string]bool{
+	AMQPPassword.Arg:          true,
+	HTTPBasicAuthPassword.Arg: true,
+	HTTPBearerToken.Arg:       true,
+	RedactKey.Arg:             true,
+}
+
 // ----------------------------------------------------------------------------
 // Context variables
 // ----------------------------------------------------------------------------
 
 var ContextVariablesForMultiPlatform = []option.ContextVariable{
+	AgeIdentityFile,
+	AgeRecipient,
+	AgeRecipientsFile,
+	AMQPCACertFile,
+	AMQPClientCertFile,
+	AMQPClientKeyFile,
+	AMQPConfirm,
+	AMQPDeclare,
+	AMQPDurable,
+	AMQPExchange,
+	AMQPExchangeType,
+	AMQPHeaders,
+	AMQPPassword,
+	AMQPPasswordFile,
+	AMQPPersistent,
+	AMQPQueue,
+	AMQPRoutingKey,
+	AMQPServerName,
+	AMQPTLSMinVersion,
+	AMQPUsername,
+	AuditManifest,
 	option.DelayInSeconds,
 	option.CoreInstanceName.SetDefault(fmt.Sprintf("move-%d", time.Now().Unix())),
+	Dedupe,
+	DedupeDivertFile,
+	DedupeKey,
+	DefaultDataSource,
+	Filter,
+	Follow,
+	FollowStateDirectory,
+	GenerateRecordID,
+	HTTPBasicAuthPassword,
+	HTTPBasicAuthUser,
+	HTTPBearerToken,
+	HTTPBearerTokenFile,
+	HTTPCACertFile,
+	HTTPClientCertFile,
+	HTTPClientKeyFile,
+	HTTPHeader,
+	HTTPProxy,
+	HTTPReadTimeout,
+	HTTPRetries,
+	HTTPTimeout,
 	option.InputFileType,
-	option.InputURL,
+	InputChecksumURL,
+	InputParallelism,
+	InputRecursive,
+	InputSHA256,
+	InputURL,
 	option.JSONOutput,
 	option.LogLevel,
+	MessageID,
 	option.MonitoringPeriodInSeconds,
+	Operation,
+	OperationAttribute,
+	OutputEnvelope,
 	option.OutputURL,
+	OutputURLFile,
 	option.RecordMax,
 	option.RecordMin,
 	option.RecordMonitor,
+	RedactDrop,
+	RedactHash,
+	RedactKey,
+	RedactMask,
+	SetDataSource,
+	SQSMessageGroupID,
+	StopOnInputError,
+	TransformFile,
+	ValidationLevel,
 }
 
 var ContextVariables = append(ContextVariablesForMultiPlatform, ContextVariablesForOsArch...)
@@ -89,7 +162,7 @@ func RunE(_ *cobra.Command, _ []string) error {
 		outputln("Run with the following parameters:")
 
 		for _, key := range viper.AllKeys() {
-			outputln("  - ", key, " = ", viper.Get(key))
+			outputln("  - ", key, " = ", displayValue(key))
 		}
 	}
 
@@ -106,37 +179,167 @@ func RunE(_ *cobra.Command, _ []string) error {
 		time.Sleep(time.Duration(viper.GetInt(option.DelayInSeconds.Arg)) * time.Second)
 	}
 
-	ctx := context.Background()
+	// Interrupting a followed input saves the position read.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := newMover().Move(ctx)
+
+	return wraperror.Errorf(move.RedactError(err, secretValues()...), wraperror.NoMessage)
+}
+
+// Used in construction of cobra.Command.
+func Version() string {
+	return cmdhelper.Version(githubVersion, githubIteration)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
 
-	mover := &move.BasicMove{
+// A mover configured from the parameters shared by move and move watch.
+func newMover() *move.BasicMove {
+	httpRetries := viper.GetInt(HTTPRetries.Arg)
+	if httpRetries == 0 {
+		// BasicMove retries by default; zero here means no retries.
+		httpRetries = move.HTTPRetriesNone
+	}
+
+	return &move.BasicMove{
+		AgeIdentityFile:           viper.GetString(AgeIdentityFile.Arg),
+		AgeRecipients:             viper.GetStringSlice(AgeRecipient.Arg),
+		AgeRecipientsFile:         viper.GetString(AgeRecipientsFile.Arg),
+		AMQPCACertFile:            viper.GetString(AMQPCACertFile.Arg),
+		AMQPClientCertFile:        viper.GetString(AMQPClientCertFile.Arg),
+		AMQPClientKeyFile:         viper.GetString(AMQPClientKeyFile.Arg),
+		AMQPConfirm:               viper.GetBool(AMQPConfirm.Arg),
+		AMQPDurable:               viper.GetBool(AMQPDurable.Arg),
+		AMQPExchange:              viper.GetString(AMQPExchange.Arg),
+		AMQPExchangeType:          viper.GetString(AMQPExchangeType.Arg),
+		AMQPHeaders:               viper.GetBool(AMQPHeaders.Arg),
+		AMQPPassword:              viper.GetString(AMQPPassword.Arg),
+		AMQPPasswordFile:          viper.GetString(AMQPPasswordFile.Arg),
+		AMQPQueue:                 viper.GetString(AMQPQueue.Arg),
+		AMQPRoutingKey:            viper.GetString(AMQPRoutingKey.Arg),
+		AMQPServerName:            viper.GetString(AMQPServerName.Arg),
+		AMQPSkipDeclare:           !viper.GetBool(AMQPDeclare.Arg),
+		AMQPTLSMinVersion:         viper.GetString(AMQPTLSMinVersion.Arg),
+		AMQPTransient:             !viper.GetBool(AMQPPersistent.Arg),
+		AMQPUsername:              viper.GetString(AMQPUsername.Arg),
+		AuditManifest:             viper.GetString(AuditManifest.Arg),
+		Configuration:             effectiveConfiguration(),
+		Dedupe:                    viper.GetString(Dedupe.Arg),
+		DedupeDivertFile:          viper.GetString(DedupeDivertFile.Arg),
+		DedupeKey:                 viper.GetString(DedupeKey.Arg),
+		DefaultDataSource:         viper.GetStringSlice(DefaultDataSource.Arg),
 		FileType:                  viper.GetString(option.InputFileType.Arg),
-		InputURL:                  viper.GetString(option.InputURL.Arg),
+		Filter:                    viper.GetString(Filter.Arg),
+		Follow:                    viper.GetBool(Follow.Arg),
+		FollowStateDirectory:      viper.GetString(FollowStateDirectory.Arg),
+		GenerateRecordID:          viper.GetString(GenerateRecordID.Arg),
+		HTTPBasicAuthPassword:     viper.GetString(HTTPBasicAuthPassword.Arg),
+		HTTPBasicAuthUser:         viper.GetString(HTTPBasicAuthUser.Arg),
+		HTTPBearerToken:           viper.GetString(HTTPBearerToken.Arg),
+		HTTPBearerTokenFile:       viper.GetString(HTTPBearerTokenFile.Arg),
+		HTTPCACertFile:            viper.GetString(HTTPCACertFile.Arg),
+		HTTPClientCertFile:        viper.GetString(HTTPClientCertFile.Arg),
+		HTTPClientKeyFile:         viper.GetString(HTTPClientKeyFile.Arg),
+		HTTPHeaders:               viper.GetStringSlice(HTTPHeader.Arg),
+		HTTPProxy:                 viper.GetString(HTTPProxy.Arg),
+		HTTPReadTimeout:           time.Duration(viper.GetInt(HTTPReadTimeout.Arg)) * time.Second,
+		HTTPRetries:               httpRetries,
+		HTTPTimeout:               time.Duration(viper.GetInt(HTTPTimeout.Arg)) * time.Second,
+		InputChecksumURL:          viper.GetString(InputChecksumURL.Arg),
+		InputParallelism:          viper.GetInt(InputParallelism.Arg),
+		InputRecursive:            viper.GetBool(InputRecursive.Arg),
+		InputSHA256:               viper.GetStringSlice(InputSHA256.Arg),
+		InputURLs:                 viper.GetStringSlice(InputURL.Arg),
 		JSONOutput:                viper.GetBool(option.JSONOutput.Arg),
 		LogLevel:                  viper.GetString(option.LogLevel.Arg),
+		MessageIDStrategy:         viper.GetString(MessageID.Arg),
 		MonitoringPeriodInSeconds: viper.GetInt(option.MonitoringPeriodInSeconds.Arg),
+		Operation:                 viper.GetStringSlice(Operation.Arg),
+		OperationAttribute:        viper.GetString(OperationAttribute.Arg),
+		OutputEnvelope:            viper.GetString(OutputEnvelope.Arg),
 		OutputURL:                 viper.GetString(option.OutputURL.Arg),
+		OutputURLFile:             viper.GetString(OutputURLFile.Arg),
 		RecordMax:                 viper.GetInt(option.RecordMax.Arg),
 		RecordMin:                 viper.GetInt(option.RecordMin.Arg),
 		RecordMonitor:             viper.GetInt(option.RecordMonitor.Arg),
+		RedactDrop:                viper.GetStringSlice(RedactDrop.Arg),
+		RedactHash:                viper.GetStringSlice(RedactHash.Arg),
+		RedactKey:                 viper.GetString(RedactKey.Arg),
+		RedactMask:                viper.GetStringSlice(RedactMask.Arg),
+		RunID:                     viper.GetString(option.CoreInstanceName.Arg),
+		SetDataSource:             viper.GetStringSlice(SetDataSource.Arg),
+		SQSMessageGroupID:         viper.GetString(SQSMessageGroupID.Arg),
+		StopOnInputError:          viper.GetBool(StopOnInputError.Arg),
+		TransformFile:             viper.GetString(TransformFile.Arg),
+		ValidationLevel:           viper.GetString(ValidationLevel.Arg),
+		Version:                   Version(),
 	}
+}
+
+// Since init() is always invoked, define command line parameters.
+func init() {
+	cmdhelper.Init(RootCmd, ContextVariables)
+}
+
+// The parameters of the run, as shown to the user, for the audit manifest.
+func effectiveConfiguration() map[string]any {
+	result := map[string]any{}
 
-	err := mover.Move(ctx)
+	for _, key := range viper.AllKeys() {
+		result[key] = displayValue(key)
+	}
 
-	return wraperror.Errorf(err, wraperror.NoMessage)
+	return result
 }
 
-// Used in construction of cobra.Command.
-func Version() string {
-	return cmdhelper.Version(githubVersion, githubIteration)
+// The value of a parameter as shown to the user; secrets are hidden and the
+// credentials in URLs are masked.
+func displayValue(key string) any {
+	if secretKeys[key] && viper.GetString(key) != "" {
+		return move.Masked
+	}
+
+	if key == HTTPHeader.Arg {
+		// Header values are often credentials; only the names are shown.
+		var headers []string
+
+		for _, header := range viper.GetStringSlice(key) {
+			name, _, _ := strings.Cut(header, ":")
+			headers = append(headers, name+": "+move.Masked)
+		}
+
+		return headers
+	}
+
+	// Credentials in URLs and secrets repeated in other parameters.
+	value := viper.Get(key)
+	text := fmt.Sprint(value)
+
+	if redacted := move.RedactText(text, secretValues()...); redacted != text {
+		return redacted
+	}
+
+	return value
 }
 
-// ----------------------------------------------------------------------------
-// Private functions
-// ----------------------------------------------------------------------------
+// The values of the secret parameters, masked wherever they might be shown.
+func secretValues() []string {
+	var result []string
 
-// Since init() is always invoked, define command line parameters.
-func init() {
-	cmdhelper.Init(RootCmd, ContextVariables)
+	for key := range secretKeys {
+		result = append(result, viper.GetString(key))
+	}
+
+	for _, header := range viper.GetStringSlice(HTTPHeader.Arg) {
+		_, value, _ := strings.Cut(header, ":")
+		result = append(result, strings.TrimSpace(value))
+	}
+
+	return result
 }
 
 func outputln(message ...any) {
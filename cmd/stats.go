@@ -0,0 +1,101 @@
+/*
+ */
+package cmd
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/move/move"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	StatsShort string = "Profile the records of an input."
+	StatsUse   string = "stats"
+	StatsLong  string = `
+    Reads every record of the input and reports the number of records per DATA_SOURCE,
+    the frequency of each attribute, the distribution of record sizes and the number
+    of records missing a DATA_SOURCE or RECORD_ID.  Records are not validated.
+
+    For example:
+
+    move stats --input-url "file:///path/to/json/lines/file.jsonl"
+    move stats --input-url "file:///path/to/json/lines/file.jsonl.gz" --stats-format json
+`
+)
+
+// ----------------------------------------------------------------------------
+// Context variables
+// ----------------------------------------------------------------------------
+
+var ContextVariablesForStats = []option.ContextVariable{
+	AgeIdentityFile,
+	option.InputFileType,
+	InputRecursive,
+	InputURL,
+	option.RecordMax,
+	option.RecordMin,
+	StatsFormat,
+}
+
+// ----------------------------------------------------------------------------
+// Command
+// ----------------------------------------------------------------------------
+
+// StatsCmd represents the stats command.
+var StatsCmd = &cobra.Command{
+	Use:   StatsUse,
+	Short: StatsShort,
+	Long:  StatsLong,
+	PreRun: func(cobraCommand *cobra.Command, args []string) {
+		cmdhelper.PreRun(cobraCommand, args, Use, ContextVariablesForStats)
+	},
+	RunE: func(cobraCommand *cobra.Command, args []string) error {
+		_ = args
+
+		return statsAction(context.Background(), cobraCommand.OutOrStdout())
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func init() {
+	RootCmd.AddCommand(StatsCmd)
+	cmdhelper.Init(StatsCmd, ContextVariablesForStats)
+}
+
+func statsAction(ctx context.Context, out io.Writer) error {
+	mover := &move.BasicMove{
+		AgeIdentityFile: viper.GetString(AgeIdentityFile.Arg),
+		FileType:        viper.GetString(option.InputFileType.Arg),
+		InputRecursive:  viper.GetBool(InputRecursive.Arg),
+		InputURLs:       viper.GetStringSlice(InputURL.Arg),
+		RecordMax:       viper.GetInt(option.RecordMax.Arg),
+		RecordMin:       viper.GetInt(option.RecordMin.Arg),
+		StatsFormat:     viper.GetString(StatsFormat.Arg),
+	}
+
+	statistics, err := mover.Stats(ctx)
+	if err != nil {
+		return wraperror.Errorf(err, "statsAction")
+	}
+
+	switch strings.ToLower(viper.GetString(StatsFormat.Arg)) {
+	case move.StatsFormatJSON:
+		err = statistics.WriteJSON(out)
+	case move.StatsFormatTable:
+		err = statistics.WriteTable(out)
+	default:
+		return wraperror.Errorf(errForPackage, "unknown stats format: %s", viper.GetString(StatsFormat.Arg))
+	}
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
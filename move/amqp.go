@@ -0,0 +1,562 @@
+package move
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/go-queueing/queues/rabbitmq"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// An amqpProducer publishes records to a RabbitMQ exchange.  The broker
+// confirms each record; the records not yet confirmed when the connection
+// fails are published again once it is reestablished.
+type amqpProducer struct {
+	bound        map[string]bool
+	channel      *amqp.Channel
+	config       amqp.Config
+	connection   *amqp.Connection
+	declare      bool
+	deliveryMode uint8
+	durable      bool
+	exchange     string
+	exchangeType string
+	headers      bool
+	move         *BasicMove
+	name         string
+	pending      []*amqpPublishing
+	queue        string
+	routingKey   string
+	templated    bool
+	url          string
+}
+
+// A record published and not yet confirmed.
+type amqpPublishing struct {
+	confirmation *amqp.DeferredConfirmation
+	record       queues.Record
+}
+
+const (
+	TLSVersion12 = "1.2"
+	TLSVersion13 = "1.3"
+)
+
+const (
+	amqpConfirmWindow       = 256
+	defaultAMQPRetryBackoff = time.Second
+	maxAMQPRetries          = 10
+	maxAMQPRetryBackoff     = 30 * time.Second
+)
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Write records to the RabbitMQ exchange and queue named by the AMQP options
+// or else the exchange, queue-name and routing-key query parameters of an
+// amqp:// or amqps:// URL.  Plain amqp:// outputs are written by the managed
+// producer, unless they need what only an amqpProducer does.
+func (move *BasicMove) writeAMQP(ctx context.Context, outputURL string, recordchan chan queues.Record) error {
+	producer, err := move.newAMQPProducer(outputURL)
+	if err != nil {
+		return err
+	}
+
+	defer producer.disconnect()
+
+	if !move.isConfirmingAMQP(outputURL) {
+		managedchan, waitForOperations := move.splitOperations(recordchan, func(operationchan chan queues.Record) error {
+			return producer.write(ctx, operationchan)
+		})
+
+		rabbitmq.StartManagedProducer(ctx, outputURL, runtime.GOMAXPROCS(0), managedchan, move.LogLevel, move.JSONOutput)
+
+		return waitForOperations()
+	}
+
+	return producer.write(ctx, recordchan)
+}
+
+// ----------------------------------------------------------------------------
+
+func (move *BasicMove) newAMQPProducer(outputURL string) (*amqpProducer, error) {
+	parsedURL, err := url.Parse(outputURL)
+	if err != nil {
+		return nil, wraperror.Errorf(RedactError(err), "url.Parse")
+	}
+
+	query := parsedURL.Query()
+
+	result := &amqpProducer{
+		config:       amqp.Config{Properties: amqp.NewConnectionProperties()},
+		declare:      !move.AMQPSkipDeclare,
+		deliveryMode: amqp.Persistent,
+		durable:      move.AMQPDurable,
+		exchange:     cmp.Or(move.AMQPExchange, query.Get("exchange")),
+		exchangeType: cmp.Or(move.AMQPExchangeType, amqp.ExchangeDirect),
+		headers:      move.AMQPHeaders,
+		move:         move,
+		name:         RedactURL(outputURL),
+		queue:        cmp.Or(move.AMQPQueue, query.Get("queue-name")),
+		url:          outputURL,
+	}
+
+	result.routingKey = cmp.Or(move.AMQPRoutingKey, query.Get("routing-key"), result.queue)
+	result.templated = recordTemplatePattern.MatchString(result.routingKey)
+
+	if move.AMQPTransient {
+		result.deliveryMode = amqp.Transient
+	}
+
+	switch {
+	case result.declare && (result.exchange == "" || result.queue == ""):
+		return nil, wraperror.Errorf(errForPackage, "please define an exchange and queue-name to declare: %s", result.name)
+	case result.exchange == "" && result.routingKey == "":
+		return nil, wraperror.Errorf(errForPackage, "please define an exchange or routing-key to publish to: %s", result.name)
+	}
+
+	result.config.Properties.SetClientConnectionName("move")
+
+	switch parsedURL.Scheme {
+	case "amqps":
+		result.config.TLSClientConfig, err = move.amqpTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		if move.AMQPCACertFile != "" || move.AMQPClientCertFile != "" || move.AMQPClientKeyFile != "" || move.AMQPServerName != "" {
+			return nil, wraperror.Errorf(errForPackage, "AMQP TLS options given for a plaintext output, use amqps://: %s", result.name)
+		}
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Report whether records must be published by an amqpProducer, confirming
+// each record: for amqps:// outputs, which the managed producer cannot
+// connect to, when confirms are requested or records acknowledged, and for
+// the AMQP options and record operations the managed producer does not know.
+// Records that carry an operation from an envelope are split off from those
+// given to the managed producer.
+func (move *BasicMove) isConfirmingAMQP(outputURL string) bool {
+	parsedURL, err := url.Parse(outputURL)
+	if err != nil || parsedURL.Scheme == "amqps" {
+		return true
+	}
+
+	hasRoutingOptions := move.AMQPExchange != "" || move.AMQPQueue != "" || move.AMQPRoutingKey != "" ||
+		(move.AMQPExchangeType != "" && move.AMQPExchangeType != amqp.ExchangeDirect)
+	hasMessageOptions := move.AMQPDurable || move.AMQPHeaders || move.AMQPSkipDeclare || move.AMQPTransient
+
+	return move.AMQPConfirm || move.isAcknowledging() || move.isTrackingOperations() || hasRoutingOptions || hasMessageOptions
+}
+
+// ----------------------------------------------------------------------------
+
+// Check the AMQP options that do not depend on the output URL.  The password
+// in the AMQP password file is kept to be masked like the password option.
+func (move *BasicMove) validateAMQPOptions() error {
+	switch move.AMQPExchangeType {
+	case "", amqp.ExchangeDirect, amqp.ExchangeFanout, amqp.ExchangeHeaders, amqp.ExchangeTopic:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown AMQP exchange type: %s", move.AMQPExchangeType)
+	}
+
+	if move.AMQPPasswordFile != "" {
+		password, err := readSecretFile(move.AMQPPasswordFile)
+		if err != nil {
+			return err
+		}
+
+		move.fileSecrets.add(password)
+	}
+
+	_, err := move.amqpTLSConfig()
+
+	return err
+}
+
+// ----------------------------------------------------------------------------
+
+// The TLS configuration for amqps:// outputs.
+func (move *BasicMove) amqpTLSConfig() (*tls.Config, error) {
+	result, err := newTLSConfig(move.AMQPCACertFile, move.AMQPClientCertFile, move.AMQPClientKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	switch move.AMQPTLSMinVersion {
+	case "", TLSVersion12:
+		result.MinVersion = tls.VersionTLS12
+	case TLSVersion13:
+		result.MinVersion = tls.VersionTLS13
+	default:
+		return nil, wraperror.Errorf(errForPackage, "unknown minimum TLS version: %s", move.AMQPTLSMinVersion)
+	}
+
+	result.ServerName = move.AMQPServerName
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Publish every record in the record channel and wait for the broker to
+// confirm them.  When records must be acknowledged, confirmations are also
+// awaited whenever no more records are waiting, so that records trickling in
+// are not held back.
+func (producer *amqpProducer) write(ctx context.Context, recordchan chan queues.Record) error {
+	retryable, err := producer.connect()
+	if err != nil {
+		if !retryable {
+			return err
+		}
+
+		err = producer.reconnect(ctx, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	for record := range recordchan {
+		err = producer.publish(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		if producer.move.isAcknowledging() && len(recordchan) == 0 {
+			err = producer.waitForConfirms(ctx, 0)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return producer.waitForConfirms(ctx, 0)
+}
+
+// ----------------------------------------------------------------------------
+
+// Publish a record.  Once the window of unconfirmed records is full, wait for
+// the oldest half to be confirmed.
+func (producer *amqpProducer) publish(ctx context.Context, record queues.Record) error {
+	publishing := &amqpPublishing{record: record}
+	producer.pending = append(producer.pending, publishing)
+
+	err := producer.send(ctx, publishing)
+	if err != nil {
+		err = producer.reconnect(ctx, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(producer.pending) >= amqpConfirmWindow {
+		return producer.waitForConfirms(ctx, amqpConfirmWindow/2)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Wait until no more than keep records are unconfirmed.  A record the broker
+// rejects, or whose connection fails, is published again.
+func (producer *amqpProducer) waitForConfirms(ctx context.Context, keep int) error {
+	for len(producer.pending) > keep {
+		acked, err := producer.pending[0].confirmation.WaitContext(ctx)
+		if err != nil {
+			return wraperror.Errorf(err, "waiting for %s to confirm", producer.name)
+		}
+
+		if acked {
+			acknowledge(producer.pending[0].record, nil)
+			producer.pending = producer.pending[1:]
+
+			continue
+		}
+
+		err = producer.reconnect(ctx, wraperror.Errorf(errForPackage, "record not confirmed"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Connect to the broker again after a failure, with an exponential backoff,
+// and publish the unconfirmed records again.  Retrying ends after
+// maxAMQPRetries attempts, when the context is done or when the broker refuses
+// the credentials or the TLS handshake fails, which retrying will not change.
+func (producer *amqpProducer) reconnect(ctx context.Context, cause error) error {
+	backoff := defaultAMQPRetryBackoff
+
+	for retries := 1; ; retries++ {
+		producer.disconnect()
+
+		if retries > maxAMQPRetries {
+			return wraperror.Errorf(errForPackage, "giving up on %s after %d retries: %v", producer.name, maxAMQPRetries, RedactError(cause))
+		}
+
+		producer.move.log(3018, producer.name, backoff, retries, maxAMQPRetries, cause)
+
+		select {
+		case <-ctx.Done():
+			return wraperror.Errorf(ctx.Err(), "writing to %s after: %v", producer.name, RedactError(cause))
+		case <-time.After(backoff):
+		}
+
+		retryable, err := producer.connect()
+		if err == nil {
+			err = producer.sendPending(ctx)
+			if err == nil {
+				return nil
+			}
+		} else if !retryable {
+			producer.disconnect()
+
+			return err
+		}
+
+		cause = err
+		backoff = min(2*backoff, maxAMQPRetryBackoff)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Open a connection and a channel in confirm mode and, unless declaring is
+// skipped, declare the exchange and queue and bind them by the routing key.
+// A templated routing key is bound as each of its values is first used.
+// Reports whether a failed connection is worth retrying.
+func (producer *amqpProducer) connect() (bool, error) {
+	connection, err := amqp.DialConfig(producer.url, producer.config)
+	if err != nil {
+		return isRetryableAMQPError(err), wraperror.Errorf(RedactError(err), "amqp.DialConfig")
+	}
+
+	producer.connection = connection
+
+	channel, err := connection.Channel()
+	if err != nil {
+		return true, wraperror.Errorf(err, "Channel")
+	}
+
+	producer.channel = channel
+
+	err = channel.Confirm(false)
+	if err != nil {
+		return true, wraperror.Errorf(err, "Confirm")
+	}
+
+	producer.bound = map[string]bool{}
+
+	if producer.declare {
+		err = producer.declareExchangeAndQueue()
+		if err != nil {
+			return true, err
+		}
+
+		if !producer.templated {
+			err = producer.bind(producer.routingKey)
+			if err != nil {
+				return true, err
+			}
+		}
+	}
+
+	producer.move.log(2010, producer.name)
+
+	return false, nil
+}
+
+// ----------------------------------------------------------------------------
+
+func (producer *amqpProducer) declareExchangeAndQueue() error {
+	err := producer.channel.ExchangeDeclare(
+		producer.exchange,     // name
+		producer.exchangeType, // type
+		producer.durable,      // durable
+		false,                 // auto-deleted
+		false,                 // internal
+		false,                 // no-wait
+		nil,                   // arguments
+	)
+	if err != nil {
+		return wraperror.Errorf(err, "ExchangeDeclare")
+	}
+
+	_, err = producer.channel.QueueDeclare(
+		producer.queue,   // name
+		producer.durable, // durable
+		false,            // delete when unused
+		false,            // exclusive
+		false,            // no-wait
+		nil,              // arguments
+	)
+	if err != nil {
+		return wraperror.Errorf(err, "QueueDeclare")
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+func (producer *amqpProducer) bind(routingKey string) error {
+	err := producer.channel.QueueBind(producer.queue, routingKey, producer.exchange, false, nil)
+	if err != nil {
+		return wraperror.Errorf(err, "QueueBind")
+	}
+
+	producer.bound[routingKey] = true
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+func (producer *amqpProducer) disconnect() {
+	if producer.connection != nil {
+		_ = producer.connection.Close()
+	}
+
+	producer.channel = nil
+	producer.connection = nil
+}
+
+// ----------------------------------------------------------------------------
+
+func (producer *amqpProducer) send(ctx context.Context, publishing *amqpPublishing) error {
+	if producer.channel == nil {
+		return wraperror.Errorf(errForPackage, "not connected to %s", producer.name)
+	}
+
+	routingKey := producer.routingKeyFor(publishing.record)
+
+	if producer.declare && !producer.bound[routingKey] {
+		err := producer.bind(routingKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	confirmation, err := producer.channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		producer.exchange,
+		routingKey,
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			Body:         []byte(producer.move.messageFor(publishing.record)),
+			ContentType:  "text/plain",
+			DeliveryMode: producer.deliveryMode,
+			Headers:      producer.headersFor(publishing.record),
+			MessageId:    publishing.record.GetMessageID(),
+		},
+	)
+	if err != nil {
+		return wraperror.Errorf(err, "PublishWithDeferredConfirmWithContext")
+	}
+
+	publishing.confirmation = confirmation
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+func (producer *amqpProducer) sendPending(ctx context.Context) error {
+	for _, publishing := range producer.pending {
+		err := producer.send(ctx, publishing)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// The routing key of a record, with the {NAME} references of a templated key
+// replaced by the values of the record's attributes.
+func (producer *amqpProducer) routingKeyFor(record queues.Record) string {
+	if !producer.templated {
+		return producer.routingKey
+	}
+
+	return expandRecordTemplate(producer.routingKey, record)
+}
+
+// ----------------------------------------------------------------------------
+
+// The message headers of a record: its operation, when tracked, and, with
+// the headers option, the input it was read from and its line.
+func (producer *amqpProducer) headersFor(record queues.Record) amqp.Table {
+	szRecord, isSzRecord := record.(*SzRecord)
+	if !isSzRecord {
+		return nil
+	}
+
+	result := amqp.Table{}
+
+	if szRecord.Operation != "" {
+		result["operation"] = szRecord.Operation
+	}
+
+	if producer.headers {
+		result["line"] = int64(szRecord.ID)
+		result["source"] = RedactURL(szRecord.Source)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Report whether connecting again may succeed where it failed: not when the
+// broker refuses the credentials or the virtual host, nor when the TLS
+// handshake fails, such as for an untrusted certificate.  A client
+// certificate the broker rejects is reported as a frame error with the TLS
+// alert the broker sent.
+func isRetryableAMQPError(err error) bool {
+	var (
+		amqpErr   *amqp.Error
+		headerErr tls.RecordHeaderError
+		verifyErr *tls.CertificateVerificationError
+	)
+
+	switch {
+	case errors.As(err, &amqpErr):
+		return amqpErr.Code != amqp.AccessRefused && !strings.HasPrefix(amqpErr.Reason, "remote error: tls:")
+	case errors.As(err, &verifyErr), errors.As(err, &headerErr):
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,784 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test writing to RabbitMQ
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_amqp(test *testing.T) {
+	if raceDetector {
+		test.Skip("the managed producer races on the state of its clients")
+	}
+
+	broker := startFakeBroker(test, nil)
+
+	outputURL := "amqp://guest:guest@" + broker.address + "/?exchange=senzing&queue-name=records"
+	moveToBroker(test, &move.BasicMove{LogLevel: "INFO", OutputURL: outputURL}, false)
+
+	messages := broker.messages()
+	require.Len(test, messages, 12)
+
+	broker.mutex.Lock()
+	require.Contains(test, broker.exchanges, fakeExchange{name: "senzing", kind: "direct"})
+	broker.mutex.Unlock()
+
+	for _, message := range messages {
+		require.Equal(test, "senzing", message.exchange)
+		require.Equal(test, "records", message.routingKey)
+		require.Equal(test, uint8(2), message.deliveryMode)
+		require.NotEmpty(test, message.messageID)
+		require.Contains(test, message.body, "RECORD_ID")
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_amqpConfirm(test *testing.T) {
+	broker := startFakeBroker(test, nil)
+
+	outputURL := "amqp://guest:guest@" + broker.address + "/?exchange=senzing&queue-name=records"
+	moveToBroker(test, &move.BasicMove{AMQPConfirm: true, OutputURL: outputURL}, false)
+
+	require.Len(test, broker.messages(), 12)
+	require.Equal(test, []fakeExchange{{name: "senzing", kind: "direct"}}, broker.exchanges)
+	require.Equal(test, []fakeQueue{{name: "records"}}, broker.queues)
+	require.Equal(test, []fakeBinding{{exchange: "senzing", queue: "records", routingKey: "records"}}, broker.bindings)
+
+	for _, message := range broker.messages() {
+		require.Equal(test, "senzing", message.exchange)
+		require.Equal(test, "records", message.routingKey)
+		require.Equal(test, uint8(2), message.deliveryMode)
+		require.NotEmpty(test, message.messageID)
+		require.Contains(test, message.body, "RECORD_ID")
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// The AMQP options take precedence over the query parameters of the URL.
+func TestBasicMove_Move_amqpOptions(test *testing.T) {
+	broker := startFakeBroker(test, nil)
+
+	mover := &move.BasicMove{
+		AMQPDurable:      true,
+		AMQPExchange:     "senzing-topic",
+		AMQPExchangeType: "topic",
+		AMQPHeaders:      true,
+		AMQPQueue:        "all-records",
+		AMQPRoutingKey:   "senzing.{DATA_SOURCE}.{MISSING}",
+		AMQPTransient:    true,
+		OutputURL:        "amqp://" + broker.address + "/?exchange=senzing&queue-name=records",
+	}
+	moveToBroker(test, mover, false)
+
+	require.Equal(test, []fakeExchange{{name: "senzing-topic", kind: "topic", durable: true}}, broker.exchanges)
+	require.Equal(test, []fakeQueue{{name: "all-records", durable: true}}, broker.queues)
+	require.Equal(test, []fakeBinding{
+		{exchange: "senzing-topic", queue: "all-records", routingKey: "senzing.ICIJ."},
+		{exchange: "senzing-topic", queue: "all-records", routingKey: "senzing.TEST."},
+	}, broker.bindings)
+
+	messages := broker.messages()
+	require.Len(test, messages, 12)
+
+	for index, message := range messages {
+		require.Equal(test, "senzing-topic", message.exchange)
+		require.Equal(test, uint8(1), message.deliveryMode)
+		require.Equal(test, int64(index+1), message.headers["line"])
+		require.Contains(test, message.headers["source"], ".jsonl")
+
+		if index < 10 {
+			require.Equal(test, "senzing.ICIJ.", message.routingKey)
+		} else {
+			require.Equal(test, "senzing.TEST.", message.routingKey)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Without declaring, records are published to existing exchanges, such as
+// the default exchange which routes to the queue named by the routing key.
+func TestBasicMove_Move_amqpSkipDeclare(test *testing.T) {
+	broker := startFakeBroker(test, nil)
+
+	mover := &move.BasicMove{
+		AMQPSkipDeclare: true,
+		OutputURL:       "amqp://" + broker.address + "/?queue-name=records",
+	}
+	moveToBroker(test, mover, false)
+
+	require.Empty(test, broker.exchanges)
+	require.Empty(test, broker.queues)
+	require.Empty(test, broker.bindings)
+
+	messages := broker.messages()
+	require.Len(test, messages, 12)
+	require.Empty(test, messages[0].exchange)
+	require.Equal(test, "records", messages[0].routingKey)
+	require.Nil(test, messages[0].headers)
+}
+
+// ----------------------------------------------------------------------------
+
+// The operation of records is sent as a header, without the other headers.
+func TestBasicMove_Move_amqpOperation(test *testing.T) {
+	broker := startFakeBroker(test, nil)
+
+	mover := &move.BasicMove{
+		Operation: []string{"delete"},
+		OutputURL: "amqp://" + broker.address + "/?exchange=senzing&queue-name=records",
+	}
+	moveToBroker(test, mover, false)
+
+	messages := broker.messages()
+	require.Len(test, messages, 12)
+
+	for _, message := range messages {
+		require.Equal(test, map[string]any{"operation": "delete"}, message.headers)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Records not confirmed when the connection drops are published again.
+func TestBasicMove_Move_amqpReconnects(test *testing.T) {
+	broker := startFakeBroker(test, nil)
+	broker.dropAfter = 5
+
+	outputURL := "amqp://" + broker.address + "/?exchange=senzing&queue-name=records&routing-key=key"
+	moveToBroker(test, &move.BasicMove{AMQPConfirm: true, OutputURL: outputURL}, false)
+
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	require.Equal(test, 2, broker.connections)
+
+	received := map[string]bool{}
+	for _, message := range broker.received {
+		require.Equal(test, "key", message.routingKey)
+
+		received[message.messageID] = true
+	}
+
+	require.Len(test, received, 12)
+}
+
+// ----------------------------------------------------------------------------
+
+// Refused credentials are not retried.
+func TestBasicMove_Move_amqpRefused(test *testing.T) {
+	broker := startFakeBroker(test, nil)
+	broker.refuse = true
+
+	outputURL := "amqp://guest:wrong@" + broker.address + "/?exchange=senzing&queue-name=records"
+	moveToBroker(test, &move.BasicMove{AMQPConfirm: true, OutputURL: outputURL}, true)
+
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	require.Equal(test, 1, broker.attempts)
+	require.Empty(test, broker.received)
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_amqps(test *testing.T) {
+	dir := test.TempDir()
+	clientCert, clientKey, clientPool := createClientCertificate(test, dir)
+
+	// The httptest certificate is for 127.0.0.1 and example.com.
+	server := httptest.NewTLSServer(nil)
+	serverCertificate := server.TLS.Certificates[0]
+	server.Close()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(test, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: serverCertificate.Certificate[0],
+	}), 0o600))
+
+	broker := startFakeBroker(test, &tls.Config{
+		Certificates: []tls.Certificate{serverCertificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientPool,
+		MinVersion:   tls.VersionTLS12,
+	})
+
+	testCases := []struct {
+		name    string
+		mover   move.BasicMove
+		wantErr bool
+	}{
+		{
+			name: "CA bundle, client certificate, server name and TLS 1.3",
+			mover: move.BasicMove{
+				AMQPCACertFile:     caFile,
+				AMQPClientCertFile: clientCert,
+				AMQPClientKeyFile:  clientKey,
+				AMQPServerName:     "example.com",
+				AMQPTLSMinVersion:  move.TLSVersion13,
+			},
+		},
+		{
+			name:    "untrusted server",
+			mover:   move.BasicMove{AMQPClientCertFile: clientCert, AMQPClientKeyFile: clientKey},
+			wantErr: true,
+		},
+		{
+			name:    "no client certificate",
+			mover:   move.BasicMove{AMQPCACertFile: caFile},
+			wantErr: true,
+		},
+		{
+			name:    "server name not in the certificate",
+			mover:   move.BasicMove{AMQPCACertFile: caFile, AMQPClientCertFile: clientCert, AMQPClientKeyFile: clientKey, AMQPServerName: "rabbitmq"},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			mover := testCase.mover
+			mover.OutputURL = "amqps://guest:guest@" + broker.address + "/?exchange=senzing&queue-name=records"
+			moveToBroker(test, &mover, testCase.wantErr)
+		})
+	}
+
+	require.Len(test, broker.messages(), 12)
+
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	// Failed TLS handshakes are not retried.
+	require.Equal(test, len(testCases), broker.attempts)
+
+	state := broker.tlsStates[0]
+	require.Equal(test, "example.com", state.ServerName)
+	require.Equal(test, uint16(tls.VersionTLS13), state.Version)
+	require.Len(test, state.PeerCertificates, 1)
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_amqpInvalidOptions(test *testing.T) {
+	testCases := []struct {
+		name  string
+		mover move.BasicMove
+	}{
+		{
+			name:  "TLS options for a plaintext URL",
+			mover: move.BasicMove{AMQPServerName: "rabbitmq", OutputURL: "amqp://rabbitmq/?exchange=e&queue-name=q"},
+		},
+		{
+			name:  "unknown minimum TLS version",
+			mover: move.BasicMove{AMQPTLSMinVersion: "1.1", OutputURL: "amqps://rabbitmq/?exchange=e&queue-name=q"},
+		},
+		{
+			name:  "client key without certificate",
+			mover: move.BasicMove{AMQPClientKeyFile: "key.pem", OutputURL: "amqps://rabbitmq/?exchange=e&queue-name=q"},
+		},
+		{
+			name:  "no queue name",
+			mover: move.BasicMove{OutputURL: "amqp://rabbitmq/?exchange=e"},
+		},
+		{
+			name:  "nothing to publish to",
+			mover: move.BasicMove{AMQPSkipDeclare: true, OutputURL: "amqp://rabbitmq/"},
+		},
+		{
+			name:  "unknown exchange type",
+			mover: move.BasicMove{AMQPExchangeType: "x-delayed", OutputURL: "amqp://rabbitmq/?exchange=e&queue-name=q"},
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			mover := testCase.mover
+			moveToBroker(test, &mover, true)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Move the test data to a broker, giving up after a couple of seconds when
+// an error is expected as the producer may retry until the context is done.
+func moveToBroker(t *testing.T, mover *move.BasicMove, wantErr bool) {
+	t.Helper()
+
+	_, writer, cleanUp := mockStdout(t)
+	defer cleanUp()
+
+	_, stderrWriter, stderrCleanUp := mockStderr(t)
+	defer stderrCleanUp()
+
+	inputFile, cleanUpTempFile := createTempDataFile(t, testGoodData, "jsonl")
+	defer cleanUpTempFile()
+
+	mover.InputURL = "file://" + inputFile
+
+	timeout := 10 * time.Second
+	if wantErr {
+		timeout = 2 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), timeout)
+	defer cancel()
+
+	err := mover.Move(ctx)
+
+	writer.Close()
+	stderrWriter.Close()
+
+	if wantErr {
+		require.Error(t, err)
+	} else {
+		require.NoError(t, err)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Fake RabbitMQ broker
+// ----------------------------------------------------------------------------
+
+// A fakeBroker speaks just enough AMQP 0-9-1 to accept the publishing of
+// records, remembering what was declared and published.
+type fakeBroker struct {
+	address     string
+	attempts    int
+	bindings    []fakeBinding
+	connections int
+	dropAfter   int
+	exchanges   []fakeExchange
+	mutex       sync.Mutex
+	queues      []fakeQueue
+	received    []fakeMessage
+	refuse      bool
+	tlsStates   []tls.ConnectionState
+}
+
+type fakeBinding struct {
+	exchange   string
+	queue      string
+	routingKey string
+}
+
+type fakeExchange struct {
+	durable bool
+	kind    string
+	name    string
+}
+
+type fakeQueue struct {
+	durable bool
+	name    string
+}
+
+type fakeMessage struct {
+	body         string
+	deliveryMode uint8
+	exchange     string
+	headers      map[string]any
+	messageID    string
+	routingKey   string
+}
+
+const (
+	frameMethod    = 1
+	frameHeartbeat = 8
+	frameEnd       = 0xCE
+)
+
+// ----------------------------------------------------------------------------
+
+// Start a broker on a local port, speaking TLS when given a configuration.
+func startFakeBroker(t *testing.T, tlsConfig *tls.Config) *fakeBroker {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	broker := &fakeBroker{address: listener.Addr().String()}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go broker.serve(conn)
+		}
+	}()
+
+	return broker
+}
+
+// ----------------------------------------------------------------------------
+
+// The messages received, without those published again after a dropped
+// connection.
+func (broker *fakeBroker) messages() []fakeMessage {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	var result []fakeMessage
+
+	seen := map[string]bool{}
+
+	for _, message := range broker.received {
+		if !seen[message.messageID] {
+			seen[message.messageID] = true
+
+			result = append(result, message)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+func (broker *fakeBroker) serve(conn net.Conn) {
+	defer conn.Close()
+
+	broker.mutex.Lock()
+	broker.attempts++
+	broker.mutex.Unlock()
+
+	if tlsConn, isTLS := conn.(*tls.Conn); isTLS {
+		if tlsConn.Handshake() != nil {
+			return
+		}
+
+		broker.mutex.Lock()
+		broker.tlsStates = append(broker.tlsStates, tlsConn.ConnectionState())
+		broker.mutex.Unlock()
+	}
+
+	reader := bufio.NewReader(conn)
+
+	protocolHeader := make([]byte, 8)
+	if _, err := io.ReadFull(reader, protocolHeader); err != nil {
+		return
+	}
+
+	broker.mutex.Lock()
+	broker.connections++
+	drop := broker.connections == 1 && broker.dropAfter > 0
+	broker.mutex.Unlock()
+
+	writeMethod(conn, 0, 10, 10, func(args *bytes.Buffer) { // connection.start
+		args.Write([]byte{0, 9})
+		writeUint32(args, 0) // server properties
+		writeLongString(args, "PLAIN")
+		writeLongString(args, "en_US")
+	})
+
+	var deliveryTag uint64
+
+	for {
+		frameType, channel, payload, err := readFrame(reader)
+		if err != nil || frameType == frameHeartbeat {
+			if err != nil {
+				return
+			}
+
+			continue
+		}
+
+		args := bytes.NewReader(payload[4:])
+
+		switch binary.BigEndian.Uint32(payload) {
+		case 10<<16 | 11: // connection.start-ok
+			if broker.refuse { // as brokers do for wrong credentials
+				return
+			}
+
+			writeMethod(conn, 0, 10, 30, func(args *bytes.Buffer) { // connection.tune
+				_ = binary.Write(args, binary.BigEndian, uint16(2047))
+				writeUint32(args, 131072)
+				_ = binary.Write(args, binary.BigEndian, uint16(0))
+			})
+		case 10<<16 | 40: // connection.open
+			writeMethod(conn, 0, 10, 41, func(args *bytes.Buffer) { writeShortString(args, "") })
+		case 10<<16 | 50: // connection.close
+			writeMethod(conn, 0, 10, 51, nil)
+
+			return
+		case 20<<16 | 10: // channel.open
+			writeMethod(conn, channel, 20, 11, func(args *bytes.Buffer) { writeUint32(args, 0) })
+		case 20<<16 | 40: // channel.close
+			writeMethod(conn, channel, 20, 41, nil)
+		case 85<<16 | 10: // confirm.select
+			writeMethod(conn, channel, 85, 11, nil)
+		case 40<<16 | 10: // exchange.declare
+			skip(args, 2)
+			exchange := fakeExchange{name: readShortString(args), kind: readShortString(args)}
+			exchange.durable = readByte(args)&0x02 != 0
+
+			broker.mutex.Lock()
+			broker.exchanges = append(broker.exchanges, exchange)
+			broker.mutex.Unlock()
+			writeMethod(conn, channel, 40, 11, nil)
+		case 50<<16 | 10: // queue.declare
+			skip(args, 2)
+			queue := fakeQueue{name: readShortString(args)}
+			queue.durable = readByte(args)&0x02 != 0
+
+			broker.mutex.Lock()
+			broker.queues = append(broker.queues, queue)
+			broker.mutex.Unlock()
+			writeMethod(conn, channel, 50, 11, func(args *bytes.Buffer) {
+				writeShortString(args, queue.name)
+				writeUint32(args, 0)
+				writeUint32(args, 0)
+			})
+		case 50<<16 | 20: // queue.bind
+			skip(args, 2)
+			binding := fakeBinding{queue: readShortString(args), exchange: readShortString(args), routingKey: readShortString(args)}
+
+			broker.mutex.Lock()
+			broker.bindings = append(broker.bindings, binding)
+			broker.mutex.Unlock()
+			writeMethod(conn, channel, 50, 21, nil)
+		case 60<<16 | 40: // basic.publish
+			skip(args, 2)
+			message := fakeMessage{exchange: readShortString(args), routingKey: readShortString(args)}
+
+			if readContent(reader, &message) != nil {
+				return
+			}
+
+			broker.mutex.Lock()
+			broker.received = append(broker.received, message)
+			dropNow := drop && len(broker.received) == broker.dropAfter
+			broker.mutex.Unlock()
+
+			if dropNow {
+				return
+			}
+
+			deliveryTag++
+
+			writeMethod(conn, channel, 60, 80, func(args *bytes.Buffer) { // basic.ack
+				_ = binary.Write(args, binary.BigEndian, deliveryTag)
+				args.WriteByte(0)
+			})
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Read the content header and body frames that follow basic.publish.
+func readContent(reader *bufio.Reader, message *fakeMessage) error {
+	_, _, payload, err := readFrame(reader)
+	if err != nil {
+		return err
+	}
+
+	header := bytes.NewReader(payload)
+	skip(header, 4) // class and weight
+
+	var bodySize uint64
+
+	_ = binary.Read(header, binary.BigEndian, &bodySize)
+
+	var flags uint16
+
+	_ = binary.Read(header, binary.BigEndian, &flags)
+
+	if flags&0x8000 != 0 { // content-type
+		readShortString(header)
+	}
+
+	if flags&0x4000 != 0 { // content-encoding
+		readShortString(header)
+	}
+
+	if flags&0x2000 != 0 {
+		message.headers = readTable(header)
+	}
+
+	if flags&0x1000 != 0 {
+		message.deliveryMode = readByte(header)
+	}
+
+	if flags&0x0800 != 0 { // priority
+		readByte(header)
+	}
+
+	for _, flag := range []uint16{0x0400, 0x0200, 0x0100} { // correlation-id, reply-to, expiration
+		if flags&flag != 0 {
+			readShortString(header)
+		}
+	}
+
+	if flags&0x0080 != 0 {
+		message.messageID = readShortString(header)
+	}
+
+	var body bytes.Buffer
+
+	for uint64(body.Len()) < bodySize {
+		_, _, payload, err := readFrame(reader)
+		if err != nil {
+			return err
+		}
+
+		body.Write(payload)
+	}
+
+	message.body = body.String()
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+func readFrame(reader *bufio.Reader) (byte, uint16, []byte, error) {
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return 0, 0, nil, err
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint32(header[3:])+1)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return 0, 0, nil, err
+	}
+
+	return header[0], binary.BigEndian.Uint16(header[1:]), payload[:len(payload)-1], nil
+}
+
+// ----------------------------------------------------------------------------
+
+func writeMethod(writer io.Writer, channel uint16, classID uint16, methodID uint16, writeArgs func(*bytes.Buffer)) {
+	var payload bytes.Buffer
+
+	_ = binary.Write(&payload, binary.BigEndian, classID)
+	_ = binary.Write(&payload, binary.BigEndian, methodID)
+
+	if writeArgs != nil {
+		writeArgs(&payload)
+	}
+
+	var frame bytes.Buffer
+
+	frame.WriteByte(frameMethod)
+	_ = binary.Write(&frame, binary.BigEndian, channel)
+	writeUint32(&frame, uint32(payload.Len())) //nolint:gosec
+	frame.Write(payload.Bytes())
+	frame.WriteByte(frameEnd)
+
+	_, _ = writer.Write(frame.Bytes())
+}
+
+// ----------------------------------------------------------------------------
+
+// Read a field table, with the value types the client writes.
+func readTable(reader *bytes.Reader) map[string]any {
+	var size uint32
+
+	_ = binary.Read(reader, binary.BigEndian, &size)
+
+	table := make([]byte, size)
+	_, _ = io.ReadFull(reader, table)
+
+	fields := bytes.NewReader(table)
+	result := map[string]any{}
+
+	for fields.Len() > 0 {
+		name := readShortString(fields)
+
+		switch kind := readByte(fields); kind {
+		case 'S':
+			var length uint32
+
+			_ = binary.Read(fields, binary.BigEndian, &length)
+
+			value := make([]byte, length)
+			_, _ = io.ReadFull(fields, value)
+			result[name] = string(value)
+		case 'I':
+			var value int32
+
+			_ = binary.Read(fields, binary.BigEndian, &value)
+			result[name] = int64(value)
+		case 'l':
+			var value int64
+
+			_ = binary.Read(fields, binary.BigEndian, &value)
+			result[name] = value
+		case 't':
+			result[name] = readByte(fields) != 0
+		default:
+			result[name] = kind
+
+			return result
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+func readByte(reader *bytes.Reader) uint8 {
+	value, _ := reader.ReadByte()
+
+	return value
+}
+
+func readShortString(reader *bytes.Reader) string {
+	value := make([]byte, readByte(reader))
+	_, _ = io.ReadFull(reader, value)
+
+	return string(value)
+}
+
+func skip(reader *bytes.Reader, count int64) {
+	_, _ = reader.Seek(count, io.SeekCurrent)
+}
+
+func writeLongString(buffer *bytes.Buffer, value string) {
+	writeUint32(buffer, uint32(len(value))) //nolint:gosec
+	buffer.WriteString(value)
+}
+
+func writeShortString(buffer *bytes.Buffer, value string) {
+	buffer.WriteByte(byte(len(value)))
+	buffer.WriteString(value)
+}
+
+func writeUint32(buffer *bytes.Buffer, value uint32) {
+	_ = binary.Write(buffer, binary.BigEndian, value)
+}
@@ -0,0 +1,212 @@
+package move
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// AuditManifest describes a run of Move, for the provenance of the records
+// it moved.  It is written to the AuditManifest file at the end of the run.
+type AuditManifest struct {
+	// The effective configuration, with secrets redacted.
+	Configuration map[string]any `json:"configuration,omitempty"`
+	// Records hooks dropped by returning no records in their place, over all
+	// inputs.
+	Dropped               int           `json:"dropped"`
+	DuplicatesConflicting int64         `json:"duplicates_conflicting"`
+	DuplicatesExact       int64         `json:"duplicates_exact"`
+	EndTime               time.Time     `json:"end_time"`
+	Error                 string        `json:"error,omitempty"`
+	Filtered              int64         `json:"filtered"`
+	Inputs                []AuditInput  `json:"inputs"`
+	Outputs               []AuditOutput `json:"outputs"`
+	// Lines read that were invalid and records a hook rejected or made
+	// invalid, over all inputs.
+	Rejected  int       `json:"rejected"`
+	RunID     string    `json:"run_id"`
+	StartTime time.Time `json:"start_time"`
+	Status    string    `json:"status"`
+	Version   string    `json:"version"`
+}
+
+// AuditInput describes the reading of an input in an AuditManifest.
+type AuditInput struct {
+	// The number of bytes read, as stored, and their SHA-256 in hex.
+	Bytes      int64  `json:"bytes"`
+	Dropped    int    `json:"dropped"`
+	Duplicates int    `json:"duplicates"`
+	Error      string `json:"error,omitempty"`
+	Filtered   int    `json:"filtered"`
+	Input      string `json:"input"`
+	Lines      int    `json:"lines"`
+	// The records moved, which hooks may make more or fewer than the lines
+	// read less those rejected, filtered and duplicate.
+	Moved    int    `json:"moved"`
+	Rejected int    `json:"rejected"`
+	SHA256   string `json:"sha256"`
+}
+
+// AuditOutput describes the writing of an output in an AuditManifest.
+type AuditOutput struct {
+	// The SHA-256, in hex, of the messages handed to the output's writer, one
+	// per line.  It is that of a JSON-Lines file written, but queues may not
+	// have received every message, such as when the run failed.
+	MessagesSHA256 string `json:"messages_sha256"`
+	Output         string `json:"output"`
+	// The number of records handed to the output's writer.
+	Records int64 `json:"records"`
+}
+
+// Statuses of runs in an AuditManifest.
+const (
+	AuditFailed    = "failed"
+	AuditSucceeded = "succeeded"
+)
+
+// The records handed to the output and the digest of their messages.
+type outputDigest struct {
+	digest  hash.Hash
+	records int64
+}
+
+// Counts and digests the bytes read from an input into its InputResult.
+type inputDigestWriter struct {
+	result *InputResult
+}
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+func (writer *inputDigestWriter) Write(data []byte) (int, error) {
+	writer.result.Bytes += int64(len(data))
+
+	return writer.result.digest.Write(data) //nolint:wrapcheck
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Hand the records read to the output, adding their messages to the digest
+// of the output.
+func (move *BasicMove) digestOutput(readchan chan queues.Record, recordchan chan queues.Record) *outputDigest {
+	result := &outputDigest{digest: sha256.New()}
+
+	go func() {
+		for record := range readchan {
+			result.records++
+			_, _ = io.WriteString(result.digest, move.messageFor(record)+"\n")
+			recordchan <- record
+		}
+
+		close(recordchan)
+	}()
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+// Write the AuditManifest of a run that started at startTime and ended with
+// err, if any.
+func (move *BasicMove) writeAuditManifest(startTime time.Time, output *outputDigest, err error) error {
+	exact, conflicting := move.Duplicates()
+	manifest := AuditManifest{
+		Configuration:         move.Configuration,
+		DuplicatesConflicting: conflicting,
+		DuplicatesExact:       exact,
+		EndTime:               time.Now().UTC(),
+		Filtered:              move.Filtered(),
+		Inputs:                []AuditInput{},
+		RunID:                 move.RunID,
+		StartTime:             startTime.UTC(),
+		Status:                AuditSucceeded,
+		Version:               move.Version,
+	}
+
+	if err != nil {
+		manifest.Error = RedactError(err).Error()
+		manifest.Status = AuditFailed
+	}
+
+	for _, result := range move.inputResults {
+		input := AuditInput{
+			Bytes:      result.Bytes,
+			Dropped:    result.Dropped,
+			Duplicates: result.Duplicates,
+			Filtered:   result.Filtered,
+			Input:      RedactURL(result.Input),
+			Lines:      result.Lines,
+			Moved:      result.Moved,
+			Rejected:   result.Rejected,
+			SHA256:     result.SHA256,
+		}
+
+		if result.Err != nil {
+			input.Error = RedactError(result.Err).Error()
+		}
+
+		manifest.Inputs = append(manifest.Inputs, input)
+		manifest.Dropped += input.Dropped
+		manifest.Rejected += input.Rejected
+	}
+
+	outputURL, _ := move.ResolveOutputURL()
+	if outputURL == "" {
+		outputURL = "stdout"
+	}
+
+	manifest.Outputs = []AuditOutput{{
+		MessagesSHA256: hex.EncodeToString(output.digest.Sum(nil)),
+		Output:         RedactURL(outputURL),
+		Records:        output.records,
+	}}
+
+	content, marshalErr := json.MarshalIndent(manifest, "", "  ")
+	if marshalErr != nil {
+		return wraperror.Errorf(marshalErr, "json.MarshalIndent")
+	}
+
+	fileName := filepath.Clean(move.AuditManifest)
+
+	writeErr := os.WriteFile(fileName, append(content, '\n'), 0o600)
+	if writeErr != nil {
+		return wraperror.Errorf(writeErr, "writing audit manifest %s", fileName)
+	}
+
+	move.log(2011, fileName)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Count and digest the bytes read from reader, as stored, in the result.
+func (result *InputResult) digestReader(reader io.Reader) io.Reader {
+	result.digest = sha256.New()
+
+	return io.TeeReader(reader, &inputDigestWriter{result: result})
+}
+
+// ----------------------------------------------------------------------------
+
+// Record the SHA-256 of the bytes read in the result.
+func (result *InputResult) sumDigest() {
+	if result.digest != nil {
+		result.SHA256 = hex.EncodeToString(result.digest.Sum(nil))
+	}
+}
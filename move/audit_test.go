@@ -0,0 +1,159 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test the audit manifest
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_auditManifest(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputFile, cleanUpTempFile := createTempDataFile(test, testBadData, "jsonl")
+	defer cleanUpTempFile()
+
+	outputDir := test.TempDir()
+	outputFile := filepath.Join(outputDir, "output.jsonl")
+	manifestFile := filepath.Join(outputDir, "audit.json")
+
+	mover := &move.BasicMove{
+		AuditManifest: manifestFile,
+		Configuration: map[string]any{"input-url": "file://" + inputFile},
+		InputURL:      "file://" + inputFile,
+		OutputURL:     "file://user:s3cret@/" + outputFile,
+		RunID:         "move-test",
+		Version:       "1.2.3",
+	}
+	err := mover.Move(test.Context())
+
+	writer.Close()
+
+	require.NoError(test, err)
+
+	content, err := os.ReadFile(manifestFile)
+	require.NoError(test, err)
+	require.NotContains(test, string(content), "s3cret")
+
+	var manifest move.AuditManifest
+	require.NoError(test, json.Unmarshal(content, &manifest))
+
+	input, err := os.ReadFile(inputFile)
+	require.NoError(test, err)
+
+	output, err := os.ReadFile(outputFile)
+	require.NoError(test, err)
+
+	moved := countLines(test, outputFile)
+	inputSum := sha256.Sum256(input)
+	outputSum := sha256.Sum256(output)
+
+	require.Equal(test, "move-test", manifest.RunID)
+	require.Equal(test, "1.2.3", manifest.Version)
+	require.Equal(test, move.AuditSucceeded, manifest.Status)
+	require.Equal(test, "file://"+inputFile, manifest.Configuration["input-url"])
+	require.False(test, manifest.EndTime.Before(manifest.StartTime))
+
+	require.Len(test, manifest.Inputs, 1)
+	require.Equal(test, int64(len(input)), manifest.Inputs[0].Bytes)
+	require.Equal(test, hex.EncodeToString(inputSum[:]), manifest.Inputs[0].SHA256)
+	require.Equal(test, moved, manifest.Inputs[0].Moved)
+	require.Equal(test, 16-moved, manifest.Inputs[0].Rejected)
+	require.Equal(test, 16-moved, manifest.Rejected)
+
+	require.Len(test, manifest.Outputs, 1)
+	require.Equal(test, int64(moved), manifest.Outputs[0].Records)
+	require.Equal(test, hex.EncodeToString(outputSum[:]), manifest.Outputs[0].MessagesSHA256)
+}
+
+// ----------------------------------------------------------------------------
+
+// Rejected, dropped, filtered and duplicate records are counted apart, and
+// records split by a hook do not make the counts go negative.
+func TestBasicMove_Move_auditManifestCounts(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	data := testDuplicateData + `{"RECORD_ID": "9"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "7"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "8"}
+`
+
+	inputFile, cleanUpTempFile := createTempDataFile(test, data, "jsonl")
+	defer cleanUpTempFile()
+
+	outputDir := test.TempDir()
+	manifestFile := filepath.Join(outputDir, "audit.json")
+
+	mover := &move.BasicMove{
+		AuditManifest: manifestFile,
+		Dedupe:        move.DedupeDrop,
+		Filter:        `DATA_SOURCE == "TEST"`,
+		InputURL:      "file://" + inputFile,
+		OutputURL:     "file://" + filepath.Join(outputDir, "output.jsonl"),
+	}
+
+	// Split records 1 and 8 in two, make record 2 invalid and drop record 7.
+	mover.AddHook("split", func(_ context.Context, record *move.SzRecord) ([]*move.SzRecord, error) {
+		switch record.ID {
+		case 1, 8:
+			copied := *record
+			copied.ID *= 10
+			copied.Body = strings.Replace(record.Body, `"RECORD_ID": "`, `"RECORD_ID": "copy-`, 1)
+
+			return []*move.SzRecord{record, &copied}, nil
+		case 2:
+			record.Body = `{"DATA_SOURCE": "TEST"}`
+		case 7:
+			return nil, nil
+		}
+
+		return []*move.SzRecord{record}, nil
+	})
+
+	// Reject the copy of record 8.
+	mover.AddHook("reject", func(_ context.Context, record *move.SzRecord) ([]*move.SzRecord, error) {
+		if record.ID == 80 {
+			return nil, errTestHook
+		}
+
+		return []*move.SzRecord{record}, nil
+	})
+
+	err := mover.Move(test.Context())
+
+	writer.Close()
+
+	require.NoError(test, err)
+
+	content, err := os.ReadFile(manifestFile)
+	require.NoError(test, err)
+
+	var manifest move.AuditManifest
+	require.NoError(test, json.Unmarshal(content, &manifest))
+
+	require.Len(test, manifest.Inputs, 1)
+	require.Equal(test, 8, manifest.Inputs[0].Lines)
+	require.Equal(test, 4, manifest.Inputs[0].Moved)
+	require.Equal(test, 3, manifest.Inputs[0].Rejected)
+	require.Equal(test, 1, manifest.Inputs[0].Dropped)
+	require.Equal(test, 1, manifest.Inputs[0].Filtered)
+	require.Equal(test, 1, manifest.Inputs[0].Duplicates)
+	require.Equal(test, 3, manifest.Rejected)
+	require.Equal(test, 1, manifest.Dropped)
+	require.Equal(test, int64(1), manifest.Filtered)
+}
@@ -0,0 +1,220 @@
+package move
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+const (
+	// The reference to the input URL in InputChecksumURL.
+	inputChecksumURLReference = "{INPUT}"
+	// Checksum files are small; anything larger is not one.
+	maxChecksumFileSize = 1024 * 1024
+	sha256HexLength     = 64
+)
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Read an input with read, verifying at its end that the SHA-256 of the
+// bytes read is the one expected for it, if any.  Records already moved
+// cannot be recalled from queues, so a mismatch is logged as well as
+// returned; a file output is removed once written, by
+// removeUnverifiedOutput.
+func (move *BasicMove) readVerified(ctx context.Context, result *InputResult, read func(*InputResult) error) error {
+	var err error
+
+	result.expectedSHA256, err = move.expectedSHA256(ctx, result.Input)
+	if err != nil {
+		return err
+	}
+
+	err = read(result)
+
+	result.sumDigest()
+
+	if err != nil || result.expectedSHA256 == "" || ctx.Err() != nil {
+		return err
+	}
+
+	if !strings.EqualFold(result.SHA256, result.expectedSHA256) {
+		result.mismatched = true
+		move.log(3020, result.Input, result.SHA256, result.expectedSHA256, result.Moved)
+
+		return wraperror.Errorf(errForPackage, "SHA-256 of %s is %s, expected %s",
+			RedactURL(result.Input), result.SHA256, result.expectedSHA256)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// The SHA-256, in lowercase hex, expected for an input: the one given for it
+// in InputSHA256, else the one for it in the checksum file at
+// InputChecksumURL.  Empty when neither is set.
+func (move *BasicMove) expectedSHA256(ctx context.Context, inputURL string) (string, error) {
+	source := inputURL
+	if parsedURL, err := url.Parse(inputURL); err == nil && parsedURL.Scheme == "file" {
+		source = parsedURL.Path
+	}
+
+	expected := move.mappingFor(source, move.InputSHA256)
+	if expected != "" || move.InputChecksumURL == "" {
+		return strings.ToLower(expected), nil
+	}
+
+	checksumURL, err := expandEnvReferences(strings.ReplaceAll(move.InputChecksumURL, inputChecksumURLReference, inputURL))
+	if err != nil {
+		return "", err
+	}
+
+	content, err := move.readChecksumFile(ctx, checksumURL)
+	if err != nil {
+		return "", err
+	}
+
+	expected = checksumFor(content, inputURL)
+	if expected == "" {
+		return "", wraperror.Errorf(errForPackage, "no SHA-256 for %s in %s", RedactURL(inputURL), RedactURL(checksumURL))
+	}
+
+	return expected, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Read a checksum file from a file:// or http(s):// URL.
+func (move *BasicMove) readChecksumFile(ctx context.Context, checksumURL string) (string, error) {
+	parsedURL, err := url.Parse(checksumURL)
+	if err != nil {
+		return "", wraperror.Errorf(RedactError(err), "invalid checksum URL: %s", RedactURL(checksumURL))
+	}
+
+	var reader io.ReadCloser
+
+	switch parsedURL.Scheme {
+	case "file":
+		reader, err = os.Open(filepath.Clean(parsedURL.Path))
+		if err != nil {
+			return "", wraperror.Errorf(err, "os.Open")
+		}
+	case "http", "https":
+		reader, err = move.openResource(ctx, checksumURL)
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", wraperror.Errorf(errForPackage, "cannot handle checksum URL: %s", RedactURL(checksumURL))
+	}
+
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxChecksumFileSize))
+	if err != nil {
+		return "", wraperror.Errorf(err, "reading %s", RedactURL(checksumURL))
+	}
+
+	return string(content), nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Remove a file:// output written with the records of inputs that failed
+// their SHA-256 checks, so that it is not taken for a complete output.  Only
+// called once the output was written, so files that existed before are kept.
+func (move *BasicMove) removeUnverifiedOutput() {
+	if !slices.ContainsFunc(move.inputResults, func(result InputResult) bool { return result.mismatched }) {
+		return
+	}
+
+	outputURL, err := move.ResolveOutputURL()
+	if err != nil {
+		return
+	}
+
+	parsedURL, err := url.Parse(outputURL)
+	if err != nil || parsedURL.Scheme != "file" {
+		return
+	}
+
+	err = os.Remove(parsedURL.Path)
+	if err != nil {
+		move.log(3022, parsedURL.Path, err)
+
+		return
+	}
+
+	move.log(3021, parsedURL.Path)
+}
+
+// ----------------------------------------------------------------------------
+
+// Check the expected checksums of inputs.
+func (move *BasicMove) validateChecksums() error {
+	if move.Follow && (len(move.InputSHA256) > 0 || move.InputChecksumURL != "") {
+		return wraperror.Errorf(errForPackage, "followed inputs never end, so their checksums cannot be verified")
+	}
+
+	for _, mapping := range move.InputSHA256 {
+		value := mapping[strings.LastIndex(mapping, "=")+1:]
+		if !isSHA256(strings.TrimSpace(value)) {
+			return wraperror.Errorf(errForPackage, "not a SHA-256 in hex: %s", value)
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The SHA-256 for an input in the content of a checksum file: either just
+// the SHA-256 or, as written by sha256sum, lines of a SHA-256 and a file
+// name, of which the one for the input's base name.
+func checksumFor(content string, inputURL string) string {
+	inputName := path.Base(inputURL)
+	if parsedURL, err := url.Parse(inputURL); err == nil && parsedURL.Path != "" {
+		inputName = path.Base(parsedURL.Path)
+	}
+
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !isSHA256(fields[0]) {
+			continue
+		}
+
+		// sha256sum marks files read in binary mode with "*".
+		if (len(fields) == 1 && len(lines) == 1) || (len(fields) > 1 && path.Base(strings.TrimPrefix(fields[1], "*")) == inputName) {
+			return strings.ToLower(fields[0])
+		}
+	}
+
+	return ""
+}
+
+// ----------------------------------------------------------------------------
+
+// Report whether value is a SHA-256 in hex.
+func isSHA256(value string) bool {
+	_, err := hex.DecodeString(value)
+
+	return err == nil && len(value) == sha256HexLength
+}
@@ -0,0 +1,188 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test verifying the checksums of inputs
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_inputSHA256(test *testing.T) {
+	goodSum := sha256Hex(testGoodData)
+	otherSum := sha256Hex(testBadData)
+
+	testCases := []struct {
+		name     string
+		mover    move.BasicMove
+		expected int
+		wantErr  bool
+	}{
+		{
+			name:     "matching",
+			mover:    move.BasicMove{InputSHA256: []string{strings.ToUpper(goodSum)}},
+			expected: 12,
+		},
+		{
+			name:     "matching for the input",
+			mover:    move.BasicMove{InputSHA256: []string{otherSum, "input.jsonl=" + goodSum}},
+			expected: 12,
+		},
+		{
+			name:     "matching with a record range",
+			mover:    move.BasicMove{InputSHA256: []string{goodSum}, RecordMax: 2},
+			expected: 2,
+		},
+		{
+			// The records are written before the end of the input is
+			// reached, so the output is then removed.
+			name:    "mismatch",
+			mover:   move.BasicMove{InputSHA256: []string{otherSum}},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			_, writer, cleanUp := mockStdout(test)
+			defer cleanUp()
+
+			dir := test.TempDir()
+			inputFile := filepath.Join(dir, "input.jsonl")
+			outputFile := filepath.Join(dir, "output.jsonl")
+			require.NoError(test, os.WriteFile(inputFile, []byte(testGoodData), 0o600))
+
+			mover := testCase.mover
+			mover.InputURL = "file://" + inputFile
+			mover.OutputURL = "file://" + outputFile
+
+			err := mover.Move(test.Context())
+			writer.Close()
+
+			if testCase.wantErr {
+				require.ErrorContains(test, err, "expected "+otherSum)
+				require.NoFileExists(test, outputFile)
+
+				return
+			}
+
+			require.NoError(test, err)
+			require.Equal(test, goodSum, mover.InputResults()[0].SHA256)
+			require.Equal(test, testCase.expected, countLines(test, outputFile))
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Checksum files published next to HTTP inputs.
+func TestBasicMove_Move_inputChecksumURL(test *testing.T) {
+	compressed := gzipContent(test, testGoodData)
+	checksums := sha256Hex(string(compressed)) + " *records.jsonl.gz\n" +
+		sha256Hex(testGoodData) + "  records.jsonl\n" +
+		sha256Hex(testBadData) + "  truncated.jsonl\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/records.jsonl.gz":
+			_, _ = writer.Write(compressed)
+		case "/records.jsonl", "/truncated.jsonl":
+			_, _ = writer.Write([]byte(testGoodData))
+		case "/SHA256SUMS":
+			_, _ = writer.Write([]byte(checksums))
+		case "/records.jsonl.sha256":
+			_, _ = writer.Write([]byte(sha256Hex(testGoodData) + "\n"))
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name        string
+		input       string
+		checksumURL string
+		wantErr     bool
+	}{
+		{name: "checksum next to the input", input: "/records.jsonl", checksumURL: "{INPUT}.sha256"},
+		{name: "checksum list", input: "/records.jsonl", checksumURL: server.URL + "/SHA256SUMS"},
+		{name: "compressed input", input: "/records.jsonl.gz", checksumURL: server.URL + "/SHA256SUMS"},
+		{name: "mismatch", input: "/truncated.jsonl", checksumURL: server.URL + "/SHA256SUMS", wantErr: true},
+		{name: "missing checksum file", input: "/records.jsonl.gz", checksumURL: "{INPUT}.sha256", wantErr: true},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			mover := &move.BasicMove{HTTPRetries: move.HTTPRetriesNone, InputChecksumURL: testCase.checksumURL}
+			testHTTPInput(test, mover, server.URL+testCase.input, testCase.wantErr)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// An output file that existed before is kept when an input fails its check.
+func TestBasicMove_Move_inputSHA256ExistingOutput(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	dir := test.TempDir()
+	inputFile := filepath.Join(dir, "input.jsonl")
+	outputFile := filepath.Join(dir, "output.jsonl")
+	require.NoError(test, os.WriteFile(inputFile, []byte(testGoodData), 0o600))
+	require.NoError(test, os.WriteFile(outputFile, []byte(testBadData), 0o600))
+
+	mover := &move.BasicMove{
+		InputSHA256: []string{sha256Hex(testBadData)},
+		InputURL:    "file://" + inputFile,
+		OutputURL:   "file://" + outputFile,
+	}
+	err := mover.Move(test.Context())
+
+	writer.Close()
+
+	require.Error(test, err)
+
+	content, err := os.ReadFile(outputFile)
+	require.NoError(test, err)
+	require.Equal(test, testBadData, string(content))
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_invalid_input_sha256(test *testing.T) {
+	mover := &move.BasicMove{
+		InputSHA256: []string{"input.jsonl=not-a-sha256"},
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+
+	mover = &move.BasicMove{
+		Follow:           true,
+		InputChecksumURL: "{INPUT}.sha256",
+	}
+
+	err = mover.Move(test.Context())
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
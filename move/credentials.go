@@ -0,0 +1,140 @@
+package move
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// References to environment variables in URLs, written ${NAME}.
+var envReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+// ResolveOutputURL returns the URL written to, assembled from OutputURL or
+// OutputURLFile, the ${NAME} environment variables it refers to and the AMQP
+// credentials.  It is assembled when writing starts so that credentials need
+// not be given in the URL, where they would show in process listings.
+func (move *BasicMove) ResolveOutputURL() (string, error) {
+	outputURL := move.OutputURL
+
+	if move.OutputURLFile != "" {
+		if outputURL != "" {
+			return "", wraperror.Errorf(errForPackage, "give either an output URL or an output URL file, not both")
+		}
+
+		content, err := readSecretFile(move.OutputURLFile)
+		if err != nil {
+			return "", err
+		}
+
+		outputURL = content
+	}
+
+	outputURL, err := expandEnvReferences(outputURL)
+	if err != nil {
+		return "", err
+	}
+
+	return move.addAMQPCredentials(outputURL)
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Set the user and password of an AMQP URL from the AMQP options.  A user or
+// password not given in the options is kept from the URL.
+func (move *BasicMove) addAMQPCredentials(outputURL string) (string, error) {
+	password := move.AMQPPassword
+
+	if move.AMQPPasswordFile != "" {
+		if password != "" {
+			return "", wraperror.Errorf(errForPackage, "give either an AMQP password or an AMQP password file, not both")
+		}
+
+		content, err := readSecretFile(move.AMQPPasswordFile)
+		if err != nil {
+			return "", err
+		}
+
+		password = content
+	}
+
+	if move.AMQPUsername == "" && password == "" {
+		return outputURL, nil
+	}
+
+	parsedURL, err := url.Parse(outputURL)
+	if err != nil {
+		return "", wraperror.Errorf(RedactError(err), "url.Parse")
+	}
+
+	if parsedURL.Scheme != "amqp" && parsedURL.Scheme != "amqps" {
+		return "", wraperror.Errorf(errForPackage, "AMQP credentials given for a non-AMQP output: %s", RedactURL(outputURL))
+	}
+
+	username := move.AMQPUsername
+	if username == "" {
+		username = parsedURL.User.Username()
+	}
+
+	if password == "" {
+		password, _ = parsedURL.User.Password()
+	}
+
+	parsedURL.User = url.UserPassword(username, password)
+
+	return parsedURL.String(), nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Replace the ${NAME} references in a URL with the values of the environment
+// variables.  A reference to a variable that is not set is an error rather
+// than an empty credential.
+func expandEnvReferences(text string) (string, error) {
+	var missing []string
+
+	result := envReferencePattern.ReplaceAllStringFunc(text, func(reference string) string {
+		name := envReferencePattern.FindStringSubmatch(reference)[1]
+
+		value, found := os.LookupEnv(name)
+		if !found {
+			missing = append(missing, name)
+		}
+
+		return value
+	})
+
+	if len(missing) > 0 {
+		return "", wraperror.Errorf(errForPackage, "environment variables not set: %s", strings.Join(missing, ", "))
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Read a file holding a secret, such as a mounted Kubernetes secret, without
+// the trailing newline.
+func readSecretFile(fileName string) (string, error) {
+	content, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return "", wraperror.Errorf(err, "os.ReadFile")
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
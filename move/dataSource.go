@@ -0,0 +1,100 @@
+package move
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Data source assignment
+// ----------------------------------------------------------------------------
+
+// Set or fill in the DATA_SOURCE of a record read from source.  Reports
+// whether the attributes were changed.
+func (move *BasicMove) applyDataSource(source string, attributes map[string]any) bool {
+	current, _ := attributes["DATA_SOURCE"].(string)
+
+	setName := move.mappingFor(source, move.SetDataSource)
+	if setName != "" {
+		if current == setName {
+			return false
+		}
+
+		attributes["DATA_SOURCE"] = setName
+
+		return true
+	}
+
+	defaultName := move.mappingFor(source, move.DefaultDataSource)
+	if defaultName != "" && hasNoDataSource(attributes) {
+		attributes["DATA_SOURCE"] = defaultName
+
+		return true
+	}
+
+	return false
+}
+
+// ----------------------------------------------------------------------------
+
+// Find the value, such as a data source name, that applies to source.  Each
+// mapping is either "VALUE", which applies to every input, or "INPUT=VALUE",
+// which applies to the input whose URL, path or base name is INPUT.  A file
+// read from a directory or glob pattern input is also matched by that input.
+// Mappings for the file take precedence over those for the input it came
+// from, which take precedence over the general one.
+func (move *BasicMove) mappingFor(source string, mappings []string) string {
+	value, found := inputMapping(source, mappings)
+	if found {
+		return value
+	}
+
+	if input, isExpanded := move.expandedFrom[source]; isExpanded {
+		value, found = inputMapping(input, mappings)
+		if found {
+			return value
+		}
+	}
+
+	for _, mapping := range mappings {
+		if !strings.Contains(mapping, "=") {
+			return strings.TrimSpace(mapping)
+		}
+	}
+
+	return ""
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Find the value of the first "INPUT=VALUE" mapping whose INPUT refers to
+// source.
+func inputMapping(source string, mappings []string) (string, bool) {
+	for _, mapping := range mappings {
+		index := strings.LastIndex(mapping, "=")
+		if index >= 0 && matchesSource(source, strings.TrimSpace(mapping[:index])) {
+			return strings.TrimSpace(mapping[index+1:]), true
+		}
+	}
+
+	return "", false
+}
+
+// Report whether the record has no usable DATA_SOURCE.
+func hasNoDataSource(attributes map[string]any) bool {
+	value, isString := attributes["DATA_SOURCE"].(string)
+
+	return !isString || strings.TrimSpace(value) == ""
+}
+
+// Report whether an input given by the user refers to source.
+func matchesSource(source string, input string) bool {
+	switch input {
+	case source, "file://" + source, filepath.Base(source):
+		return true
+	default:
+		return false
+	}
+}
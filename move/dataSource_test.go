@@ -0,0 +1,104 @@
+package move_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test DATA_SOURCE assignment while processing
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_processJSONL_data_source(test *testing.T) {
+	input := `{"DATA_SOURCE": "VENDOR", "RECORD_ID": "1"}
+{"RECORD_ID": "2", "NAME_FULL": "Smith & Sons"}
+{"DATA_SOURCE": "", "RECORD_ID": "3"}
+`
+
+	testCases := []struct {
+		name              string
+		source            string
+		defaultDataSource []string
+		setDataSource     []string
+		expected          []string
+	}{
+		{
+			name:     "no options",
+			source:   "/data/vendor.jsonl",
+			expected: []string{`{"DATA_SOURCE": "VENDOR", "RECORD_ID": "1"}`},
+		},
+		{
+			name:              "default data source",
+			source:            "/data/vendor.jsonl",
+			defaultDataSource: []string{"CUSTOMERS"},
+			expected: []string{
+				`{"DATA_SOURCE": "VENDOR", "RECORD_ID": "1"}`,
+				`{"DATA_SOURCE":"CUSTOMERS","NAME_FULL":"Smith & Sons","RECORD_ID":"2"}`,
+				`{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"3"}`,
+			},
+		},
+		{
+			name:          "set data source",
+			source:        "/data/vendor.jsonl",
+			setDataSource: []string{"CUSTOMERS"},
+			expected: []string{
+				`{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1"}`,
+				`{"DATA_SOURCE":"CUSTOMERS","NAME_FULL":"Smith & Sons","RECORD_ID":"2"}`,
+				`{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"3"}`,
+			},
+		},
+		{
+			name:          "set data source per input",
+			source:        "/data/vendor.jsonl",
+			setDataSource: []string{"CUSTOMERS", "vendor.jsonl=WATCHLIST", "other.jsonl=OTHER"},
+			expected: []string{
+				`{"DATA_SOURCE":"WATCHLIST","RECORD_ID":"1"}`,
+				`{"DATA_SOURCE":"WATCHLIST","NAME_FULL":"Smith & Sons","RECORD_ID":"2"}`,
+				`{"DATA_SOURCE":"WATCHLIST","RECORD_ID":"3"}`,
+			},
+		},
+		{
+			name:              "default data source for another input",
+			source:            "/data/vendor.jsonl",
+			defaultDataSource: []string{"file:///data/other.jsonl=OTHER"},
+			expected:          []string{`{"DATA_SOURCE": "VENDOR", "RECORD_ID": "1"}`},
+		},
+		{
+			name:              "default data source by URL",
+			source:            "/data/vendor.jsonl",
+			defaultDataSource: []string{"file:///data/vendor.jsonl=CUSTOMERS"},
+			expected: []string{
+				`{"DATA_SOURCE": "VENDOR", "RECORD_ID": "1"}`,
+				`{"DATA_SOURCE":"CUSTOMERS","NAME_FULL":"Smith & Sons","RECORD_ID":"2"}`,
+				`{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"3"}`,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			_, writer, cleanUp := mockStdout(test)
+			defer cleanUp()
+
+			recordchan := make(chan queues.Record, 5)
+
+			mover := &move.BasicMove{
+				DefaultDataSource: testCase.defaultDataSource,
+				SetDataSource:     testCase.setDataSource,
+			}
+			mover.ProcessJSONL(testCase.source, strings.NewReader(input), recordchan)
+
+			writer.Close()
+
+			actual := []string{}
+			for record := range recordchan {
+				actual = append(actual, record.GetMessage())
+			}
+
+			require.Equal(test, testCase.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,582 @@
+package move
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Kinds of duplicates found by a dedupeSet.
+const (
+	notDuplicate = iota
+	exactDuplicate
+	conflictingDuplicate
+)
+
+const (
+	DedupeDrop   = "drop"
+	DedupeOff    = "off"
+	DedupeReport = "report"
+)
+
+const (
+	bloomFilterBits         = 1 << 26 // 8 MiB
+	bloomFilterHashes       = 7
+	defaultDedupeMaxEntries = 1000000
+	dedupeDigestSize        = 16
+	dedupeEntrySize         = 2 * dedupeDigestSize
+	maxDedupeRuns           = 8
+)
+
+type dedupeDigest [dedupeDigestSize]byte
+
+// A memory-bounded set of record keys, each with the digest of the content
+// last seen for the key.  A Bloom filter answers most lookups for new keys.
+// Keys the filter may have seen are checked exactly: first against the
+// entries in memory, then against sorted runs spilled to disk whenever the
+// entries in memory reach maxEntries.
+type dedupeSet struct {
+	bloom      []uint64
+	dir        string
+	maxEntries int
+	memory     map[dedupeDigest]dedupeDigest
+	runs       []*os.File
+}
+
+// The state of duplicate detection for a BasicMove.
+type deduplicator struct {
+	conflicting int64
+	divert      *bufio.Writer
+	divertFile  *os.File
+	exact       int64
+	mutex       sync.Mutex
+	set         *dedupeSet
+}
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+// Duplicates returns the number of exact duplicates, records whose key had
+// last been seen with the same content, and conflicting duplicates, records
+// whose key had last been seen with different content.
+func (move *BasicMove) Duplicates() (int64, int64) {
+	if move.dedupe == nil {
+		return 0, 0
+	}
+
+	move.dedupe.mutex.Lock()
+	defer move.dedupe.mutex.Unlock()
+
+	return move.dedupe.exact, move.dedupe.conflicting
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Report whether duplicate detection is turned on.
+func (move *BasicMove) isDeduping() bool {
+	switch strings.ToLower(move.Dedupe) {
+	case DedupeDrop, DedupeReport:
+		return true
+	default:
+		return false
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Check a valid record for duplicates.  Reports whether the record should
+// still be moved: all but exact duplicates, when dropping.
+func (move *BasicMove) checkDuplicate(record *SzRecord) (bool, error) {
+	dedupe := move.dedupe
+	if dedupe == nil {
+		return true, nil
+	}
+
+	key, content, err := move.dedupeDigests(record.Body)
+	if err != nil {
+		return false, err
+	}
+
+	dedupe.mutex.Lock()
+	defer dedupe.mutex.Unlock()
+
+	if dedupe.set == nil {
+		dedupe.set = newDedupeSet(move.DedupeMaxEntries)
+	}
+
+	kind, err := dedupe.set.check(key, content)
+	if err != nil {
+		return false, err
+	}
+
+	switch kind {
+	case exactDuplicate:
+		dedupe.exact++
+
+		move.log(3013, record.ID, record.GetMessageID(), "exact duplicate")
+	case conflictingDuplicate:
+		// A conflicting duplicate is an update of the record, so it is
+		// moved even when dropping, leaving the last one read to loaders.
+		dedupe.conflicting++
+
+		move.log(3013, record.ID, record.GetMessageID(), "conflicting duplicate, moved as an update")
+
+		return true, nil
+	default:
+		return true, nil
+	}
+
+	if !strings.EqualFold(move.Dedupe, DedupeDrop) {
+		return true, nil
+	}
+
+	return false, move.divertDuplicate(record)
+}
+
+// ----------------------------------------------------------------------------
+
+// Start duplicate detection for a run, if it is turned on.  This must be done
+// before any input is read, as inputs are read concurrently.
+func (move *BasicMove) startDedupe() {
+	move.dedupe = nil
+	if move.isDeduping() {
+		move.dedupe = &deduplicator{}
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Release the resources used by duplicate detection and log the counts.
+func (move *BasicMove) closeDedupe() error {
+	if move.dedupe == nil {
+		return nil
+	}
+
+	dedupe := move.dedupe
+
+	dedupe.mutex.Lock()
+	defer dedupe.mutex.Unlock()
+
+	move.log(2004, dedupe.exact, dedupe.conflicting)
+
+	var err error
+
+	if dedupe.divert != nil {
+		err = dedupe.divert.Flush()
+		if closeErr := dedupe.divertFile.Close(); err == nil {
+			err = closeErr
+		}
+
+		dedupe.divert = nil
+		dedupe.divertFile = nil
+	}
+
+	if dedupe.set != nil {
+		if closeErr := dedupe.set.close(); err == nil {
+			err = closeErr
+		}
+
+		dedupe.set = nil
+	}
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+
+// The digests of a record's key and content.  When deduplicating on content,
+// the content is the key.
+func (move *BasicMove) dedupeDigests(body string) (dedupeDigest, dedupeDigest, error) {
+	var key dedupeDigest
+
+	attributes, err := parseRecordBody(body)
+	if err != nil {
+		return key, key, err
+	}
+
+	canonical, err := formatRecordBody(attributes)
+	if err != nil {
+		return key, key, err
+	}
+
+	content := digest(canonical)
+
+	if strings.EqualFold(move.DedupeKey, MessageIDContentHash) {
+		return content, content, nil
+	}
+
+	dataSource, _ := attributes["DATA_SOURCE"].(string)
+	recordID, _ := attributes["RECORD_ID"].(string)
+
+	return digest(dataSource + "\x00" + recordID), content, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Write a dropped duplicate to the divert file, if there is one.  The caller
+// holds the deduplicator's mutex.
+func (move *BasicMove) divertDuplicate(record *SzRecord) error {
+	if move.DedupeDivertFile == "" {
+		return nil
+	}
+
+	dedupe := move.dedupe
+
+	if dedupe.divert == nil {
+		fileName := filepath.Clean(move.DedupeDivertFile)
+
+		file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return wraperror.Errorf(err, "fatal error opening %s", fileName)
+		}
+
+		dedupe.divertFile = file
+		dedupe.divert = bufio.NewWriter(file)
+	}
+
+	_, err := dedupe.divert.WriteString(record.Body + "\n")
+
+	return wraperror.Errorf(err, "error writing to %s", move.DedupeDivertFile)
+}
+
+// ----------------------------------------------------------------------------
+// dedupeSet implementation
+// ----------------------------------------------------------------------------
+
+func newDedupeSet(maxEntries int) *dedupeSet {
+	if maxEntries <= 0 {
+		maxEntries = defaultDedupeMaxEntries
+	}
+
+	return &dedupeSet{
+		bloom:      make([]uint64, bloomFilterBits/64), //nolint:mnd
+		maxEntries: maxEntries,
+		memory:     map[dedupeDigest]dedupeDigest{},
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Look up a key, adding it with its content digest if it has not been seen
+// and replacing the digest it was seen with if the content differs.
+func (set *dedupeSet) check(key dedupeDigest, content dedupeDigest) (int, error) {
+	kind := notDuplicate
+
+	if set.mayContain(key) {
+		seen, found, err := set.lookup(key)
+		if err != nil {
+			return notDuplicate, err
+		}
+
+		if found {
+			if seen == content {
+				return exactDuplicate, nil
+			}
+
+			kind = conflictingDuplicate
+		}
+	}
+
+	set.addToBloom(key)
+	set.memory[key] = content
+
+	if len(set.memory) >= set.maxEntries {
+		return kind, set.spill()
+	}
+
+	return kind, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Remove the runs spilled to disk.
+func (set *dedupeSet) close() error {
+	err := set.removeRuns()
+
+	if set.dir != "" {
+		if removeErr := os.RemoveAll(set.dir); err == nil {
+			err = removeErr
+		}
+	}
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+
+// Close and delete the runs on disk.
+func (set *dedupeSet) removeRuns() error {
+	var err error
+
+	for _, run := range set.runs {
+		if closeErr := run.Close(); err == nil {
+			err = closeErr
+		}
+
+		if removeErr := os.Remove(run.Name()); err == nil {
+			err = removeErr
+		}
+	}
+
+	set.runs = nil
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+
+func (set *dedupeSet) addToBloom(key dedupeDigest) {
+	for _, bit := range bloomBits(key) {
+		set.bloom[bit/64] |= 1 << (bit % 64) //nolint:mnd
+	}
+}
+
+func (set *dedupeSet) mayContain(key dedupeDigest) bool {
+	for _, bit := range bloomBits(key) {
+		if set.bloom[bit/64]&(1<<(bit%64)) == 0 { //nolint:mnd
+			return false
+		}
+	}
+
+	return true
+}
+
+// ----------------------------------------------------------------------------
+
+// Find the content digest of a key, in memory or in the runs on disk, newest
+// first.
+func (set *dedupeSet) lookup(key dedupeDigest) (dedupeDigest, bool, error) {
+	if content, found := set.memory[key]; found {
+		return content, true, nil
+	}
+
+	for _, run := range slices.Backward(set.runs) {
+		content, found, err := searchRun(run, key)
+		if err != nil || found {
+			return content, found, err
+		}
+	}
+
+	return dedupeDigest{}, false, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Merge all of the runs on disk into a single run.  A key in several runs
+// keeps its entry in the newest.
+func (set *dedupeSet) merge() error {
+	merged, err := os.CreateTemp(set.dir, "run-*")
+	if err != nil {
+		return wraperror.Errorf(err, "os.CreateTemp")
+	}
+
+	readers := make([]*bufio.Reader, len(set.runs))
+	heads := make([][]byte, len(set.runs))
+
+	for index, run := range set.runs {
+		_, err = run.Seek(0, io.SeekStart)
+		if err != nil {
+			return wraperror.Errorf(err, "Seek")
+		}
+
+		readers[index] = bufio.NewReader(run)
+
+		heads[index], err = readEntry(readers[index])
+		if err != nil {
+			return err
+		}
+	}
+
+	writer := bufio.NewWriter(merged)
+
+	for {
+		smallest := -1
+
+		for index, head := range heads {
+			if head != nil && (smallest < 0 || bytes.Compare(head[:dedupeDigestSize], heads[smallest][:dedupeDigestSize]) <= 0) {
+				smallest = index
+			}
+		}
+
+		if smallest < 0 {
+			break
+		}
+
+		entry := heads[smallest]
+
+		_, err = writer.Write(entry)
+		if err != nil {
+			return wraperror.Errorf(err, "Write")
+		}
+
+		for index, head := range heads {
+			if head == nil || !bytes.Equal(head[:dedupeDigestSize], entry[:dedupeDigestSize]) {
+				continue
+			}
+
+			heads[index], err = readEntry(readers[index])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return wraperror.Errorf(err, "Flush")
+	}
+
+	err = set.removeRuns()
+	if err != nil {
+		return err
+	}
+
+	set.runs = []*os.File{merged}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Write the entries in memory to a new sorted run on disk.
+func (set *dedupeSet) spill() error {
+	var err error
+
+	if set.dir == "" {
+		set.dir, err = os.MkdirTemp("", "move-dedupe-")
+		if err != nil {
+			return wraperror.Errorf(err, "os.MkdirTemp")
+		}
+	}
+
+	keys := make([]dedupeDigest, 0, len(set.memory))
+	for key := range set.memory {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b dedupeDigest) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	run, err := os.CreateTemp(set.dir, "run-*")
+	if err != nil {
+		return wraperror.Errorf(err, "os.CreateTemp")
+	}
+
+	writer := bufio.NewWriter(run)
+
+	for _, key := range keys {
+		content := set.memory[key]
+
+		_, err = writer.Write(append(key[:], content[:]...))
+		if err != nil {
+			return wraperror.Errorf(err, "Write")
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return wraperror.Errorf(err, "Flush")
+	}
+
+	set.runs = append(set.runs, run)
+	set.memory = map[dedupeDigest]dedupeDigest{}
+
+	if len(set.runs) > maxDedupeRuns {
+		return set.merge()
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The Bloom filter bits for a key, by double hashing the two halves of the
+// key's digest.
+func bloomBits(key dedupeDigest) [bloomFilterHashes]uint64 {
+	var result [bloomFilterHashes]uint64
+
+	first := binary.LittleEndian.Uint64(key[:8])
+	second := binary.LittleEndian.Uint64(key[8:])
+
+	for index := range result {
+		result[index] = (first + uint64(index)*second) % bloomFilterBits //nolint:gosec
+	}
+
+	return result
+}
+
+// The truncated SHA-256 of a string.
+func digest(value string) dedupeDigest {
+	var result dedupeDigest
+
+	sum := sha256.Sum256([]byte(value))
+	copy(result[:], sum[:dedupeDigestSize])
+
+	return result
+}
+
+// Read the next entry of a run, or nil at the end of the run.
+func readEntry(reader *bufio.Reader) ([]byte, error) {
+	entry := make([]byte, dedupeEntrySize)
+
+	_, err := io.ReadFull(reader, entry)
+	if err == io.EOF { //nolint:errorlint
+		return nil, nil
+	}
+
+	return entry, wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// Binary search a sorted run on disk for a key.
+func searchRun(run *os.File, key dedupeDigest) (dedupeDigest, bool, error) {
+	var content dedupeDigest
+
+	info, err := run.Stat()
+	if err != nil {
+		return content, false, wraperror.Errorf(err, "Stat")
+	}
+
+	entry := make([]byte, dedupeEntrySize)
+	low, high := int64(0), info.Size()/dedupeEntrySize
+
+	for low < high {
+		middle := (low + high) / 2 //nolint:mnd
+
+		_, err = run.ReadAt(entry, middle*dedupeEntrySize)
+		if err != nil {
+			return content, false, wraperror.Errorf(err, "ReadAt")
+		}
+
+		switch bytes.Compare(entry[:dedupeDigestSize], key[:]) {
+		case 0:
+			copy(content[:], entry[dedupeDigestSize:])
+
+			return content, true, nil
+		case -1:
+			low = middle + 1
+		default:
+			high = middle
+		}
+	}
+
+	return content, false, nil
+}
@@ -0,0 +1,254 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+var testDuplicateData = `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "NAME_FULL": "Bob Smith"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "2", "NAME_FULL": "Mary Smith"}
+{"RECORD_ID": "1", "NAME_FULL": "Bob Smith", "DATA_SOURCE": "TEST"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "NAME_FULL": "Robert Smith"}
+{"DATA_SOURCE": "OTHER", "RECORD_ID": "1", "NAME_FULL": "Bob Smith"}
+`
+
+// ----------------------------------------------------------------------------
+// test duplicate detection while processing
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_processJSONL_dedupe(test *testing.T) {
+	testCases := []struct {
+		name                string
+		dedupe              string
+		dedupeKey           string
+		expectedMoved       int
+		expectedExact       int64
+		expectedConflicting int64
+	}{
+		{
+			name:          "off",
+			dedupe:        move.DedupeOff,
+			expectedMoved: 5,
+		},
+		{
+			name:                "report",
+			dedupe:              move.DedupeReport,
+			expectedMoved:       5,
+			expectedExact:       1,
+			expectedConflicting: 1,
+		},
+		{
+			name:                "drop",
+			dedupe:              move.DedupeDrop,
+			expectedMoved:       4,
+			expectedExact:       1,
+			expectedConflicting: 1,
+		},
+		{
+			name:          "drop by content",
+			dedupe:        move.DedupeDrop,
+			dedupeKey:     move.MessageIDContentHash,
+			expectedMoved: 4,
+			expectedExact: 1,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			_, writer, cleanUp := mockStdout(test)
+			defer cleanUp()
+
+			recordchan := make(chan queues.Record, 5)
+
+			mover := &move.BasicMove{
+				Dedupe:    testCase.dedupe,
+				DedupeKey: testCase.dedupeKey,
+			}
+			mover.ProcessJSONL("test.jsonl", strings.NewReader(testDuplicateData), recordchan)
+
+			writer.Close()
+
+			actual := 0
+			for range recordchan {
+				actual++
+			}
+
+			exact, conflicting := mover.Duplicates()
+			require.Equal(test, testCase.expectedMoved, actual)
+			require.Equal(test, testCase.expectedExact, exact)
+			require.Equal(test, testCase.expectedConflicting, conflicting)
+		})
+	}
+}
+
+func TestBasicMove_ProcessJSONL_dedupe_divert(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	divertFile := filepath.Join(test.TempDir(), "duplicates.jsonl")
+	recordchan := make(chan queues.Record, 5)
+
+	mover := &move.BasicMove{
+		Dedupe:           move.DedupeDrop,
+		DedupeDivertFile: divertFile,
+	}
+	mover.ProcessJSONL("test.jsonl", strings.NewReader(testDuplicateData), recordchan)
+
+	writer.Close()
+
+	actual := 0
+	for range recordchan {
+		actual++
+	}
+
+	require.Equal(test, 4, actual)
+	require.Equal(test, 1, countLines(test, divertFile))
+}
+
+// ----------------------------------------------------------------------------
+// test duplicate detection while moving
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_dedupe_divert(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputFile, cleanUpTempFile := createTempDataFile(test, testDuplicateData, "jsonl")
+	test.Cleanup(cleanUpTempFile)
+
+	outputDir := test.TempDir()
+	outputFile := filepath.Join(outputDir, "output.jsonl")
+	divertFile := filepath.Join(outputDir, "duplicates.jsonl")
+
+	mover := &move.BasicMove{
+		Dedupe:           move.DedupeDrop,
+		DedupeDivertFile: divertFile,
+		InputURL:         "file://" + inputFile,
+		OutputURL:        "file://" + outputFile,
+	}
+
+	err := mover.Move(test.Context())
+	writer.Close()
+	require.NoError(test, err)
+
+	require.Equal(test, 4, countLines(test, outputFile))
+	require.Equal(test, 1, countLines(test, divertFile))
+}
+
+// Use few enough entries in memory that the set spills and merges runs.
+func TestBasicMove_Move_dedupe_spill_to_disk(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	var builder strings.Builder
+
+	for id := range 40 {
+		fmt.Fprintf(&builder, `{"DATA_SOURCE": "TEST", "RECORD_ID": "%d"}`+"\n", id)
+	}
+
+	for id := range 40 {
+		if id%2 == 0 {
+			fmt.Fprintf(&builder, `{"DATA_SOURCE": "TEST", "RECORD_ID": "%d"}`+"\n", id)
+		} else {
+			fmt.Fprintf(&builder, `{"DATA_SOURCE": "TEST", "RECORD_ID": "%d", "NAME_FULL": "changed"}`+"\n", id)
+		}
+	}
+
+	// Changed back, which conflicts with the last record read for the key.
+	for id := range 40 {
+		fmt.Fprintf(&builder, `{"DATA_SOURCE": "TEST", "RECORD_ID": "%d"}`+"\n", id)
+	}
+
+	inputFile, cleanUpTempFile := createTempDataFile(test, builder.String(), "jsonl")
+	test.Cleanup(cleanUpTempFile)
+
+	outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+	mover := &move.BasicMove{
+		Dedupe:           move.DedupeDrop,
+		DedupeMaxEntries: 3,
+		InputURL:         "file://" + inputFile,
+		OutputURL:        "file://" + outputFile,
+	}
+
+	err := mover.Move(test.Context())
+	writer.Close()
+	require.NoError(test, err)
+
+	exact, conflicting := mover.Duplicates()
+	require.Equal(test, int64(40), exact)
+	require.Equal(test, int64(40), conflicting)
+	require.Equal(test, 80, countLines(test, outputFile))
+}
+
+// Inputs read in parallel share the duplicate detection; run with -race.
+func TestBasicMove_Move_dedupe_parallel_inputs(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	var inputURLs []string
+
+	for range 4 {
+		inputFile, cleanUpTempFile := createTempDataFile(test, testDuplicateData, "jsonl")
+		test.Cleanup(cleanUpTempFile)
+
+		inputURLs = append(inputURLs, "file://"+inputFile)
+	}
+
+	outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+	mover := &move.BasicMove{
+		Dedupe:           move.DedupeDrop,
+		InputParallelism: len(inputURLs),
+		InputURLs:        inputURLs,
+		OutputURL:        "file://" + outputFile,
+	}
+
+	err := mover.Move(test.Context())
+	writer.Close()
+	require.NoError(test, err)
+
+	// Which content is seen first for a key depends on the order of reading.
+	// Conflicting duplicates are moved.
+	exact, conflicting := mover.Duplicates()
+	require.Equal(test, int64(17), exact+conflicting)
+	require.Equal(test, 3+int(conflicting), countLines(test, outputFile))
+}
+
+func TestBasicMove_Move_unknown_dedupe(test *testing.T) {
+	mover := &move.BasicMove{
+		Dedupe: "bad",
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+
+	mover = &move.BasicMove{
+		DedupeKey: "bad",
+	}
+
+	err = mover.Move(test.Context())
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Count the lines of a file.
+func countLines(t *testing.T, fileName string) int {
+	t.Helper()
+
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+
+	return strings.Count(string(content), "\n")
+}
@@ -0,0 +1,199 @@
+package move
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The delivery of the records of an input that is filed away, when watching,
+// or whose position is saved, when following, only once the writer has
+// acknowledged its records: written and flushed to a file, confirmed by a
+// broker or accepted by a queue.
+type delivery struct {
+	delivered int
+	done      chan struct{}
+	err       error
+	mutex     sync.Mutex
+	pending   int
+	sealed    bool
+}
+
+// Records written but not yet flushed, to be acknowledged once they are.
+type flushAcknowledger struct {
+	records []queues.Record
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+func newDelivery() *delivery {
+	return &delivery{done: make(chan struct{})}
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Report whether the writer must acknowledge records, which needs writers
+// that can confirm records one at a time.
+func (move *BasicMove) isAcknowledging() bool {
+	return move.Follow || move.WatchDirectory != ""
+}
+
+// ----------------------------------------------------------------------------
+
+// Count a record handed to the writer.
+func (delivery *delivery) add() {
+	delivery.mutex.Lock()
+	defer delivery.mutex.Unlock()
+
+	delivery.pending++
+}
+
+// ----------------------------------------------------------------------------
+
+// Count a record acknowledged by the writer, or that it failed to deliver.
+func (delivery *delivery) acknowledge(err error) {
+	delivery.mutex.Lock()
+	defer delivery.mutex.Unlock()
+
+	delivery.pending--
+
+	switch {
+	case err == nil:
+		delivery.delivered++
+	case delivery.err == nil:
+		delivery.err = err
+	}
+
+	delivery.checkDone()
+}
+
+// ----------------------------------------------------------------------------
+
+// Note that every record of the input has been handed to the writer.
+func (delivery *delivery) seal() {
+	delivery.mutex.Lock()
+	defer delivery.mutex.Unlock()
+
+	delivery.sealed = true
+	delivery.checkDone()
+}
+
+// ----------------------------------------------------------------------------
+
+// Wait until the writer has acknowledged every record of the input.  Returns
+// the number of records delivered and the first delivery error, if any.
+func (delivery *delivery) wait(ctx context.Context) (int, error) {
+	select {
+	case <-delivery.done:
+	default:
+		select {
+		case <-ctx.Done():
+			return 0, wraperror.Errorf(context.Cause(ctx), "waiting for records to be delivered")
+		case <-delivery.done:
+		}
+	}
+
+	delivery.mutex.Lock()
+	defer delivery.mutex.Unlock()
+
+	return delivery.delivered, delivery.err
+}
+
+// ----------------------------------------------------------------------------
+
+// Must be called with the mutex locked.
+func (delivery *delivery) checkDone() {
+	if delivery.sealed && delivery.pending == 0 {
+		select {
+		case <-delivery.done:
+		default:
+			close(delivery.done)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Note a record written, to be acknowledged once flushed.
+func (acknowledger *flushAcknowledger) add(record queues.Record) {
+	if deliveryOf(record) != nil {
+		acknowledger.records = append(acknowledger.records, record)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Flush, once no more records are waiting, and acknowledge the records
+// written, so that records trickling in are not held back.
+func (acknowledger *flushAcknowledger) flushIdle(recordchan chan queues.Record, flush func() error) error {
+	if len(acknowledger.records) == 0 || len(recordchan) > 0 {
+		return nil
+	}
+
+	err := flush()
+
+	for _, record := range acknowledger.records {
+		acknowledge(record, err)
+	}
+
+	acknowledger.records = nil
+
+	return err
+}
+
+// ----------------------------------------------------------------------------
+
+// Hand a record of an input to the writer, counting it in the input's
+// delivery, if any.
+func (result *InputResult) send(record *SzRecord, recordchan chan queues.Record) {
+	if result.delivery != nil {
+		record.delivery = result.delivery
+		result.delivery.add()
+	}
+
+	recordchan <- record
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Acknowledge the delivery of a record, or the failure to deliver it.
+func acknowledge(record queues.Record, err error) {
+	if delivery := deliveryOf(record); delivery != nil {
+		delivery.acknowledge(err)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Report whether ctx is done because reading was stopped, rather than because
+// the writer failed.
+func isStopped(ctx context.Context) bool {
+	cause := context.Cause(ctx)
+
+	return errors.Is(cause, context.Canceled) || errors.Is(cause, context.DeadlineExceeded)
+}
+
+// ----------------------------------------------------------------------------
+
+// The delivery a record counts in, if any.
+func deliveryOf(record queues.Record) *delivery {
+	szRecord, isSzRecord := record.(*SzRecord)
+	if !isSzRecord {
+		return nil
+	}
+
+	return szRecord.delivery
+}
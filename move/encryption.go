@@ -0,0 +1,174 @@
+package move
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The suffix of files encrypted with age, after that of their format, such
+// as records.jsonl.gz.age.
+const ageSuffix = ".age"
+
+// Closes a writer that does not need closing.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// A reader of the records in an input, decrypting inputs whose names end in
+// ".age", binary or armored, with the AgeIdentityFile.
+func (move *BasicMove) decryptReader(source string, reader io.Reader) (io.Reader, error) {
+	if !strings.HasSuffix(source, ageSuffix) {
+		return reader, nil
+	}
+
+	if len(move.ageIdentities) == 0 {
+		return nil, wraperror.Errorf(errForPackage, "please define an age identity file to decrypt %s", RedactURL(source))
+	}
+
+	buffered := bufio.NewReader(reader)
+
+	header, _ := buffered.Peek(len(armor.Header))
+	if bytes.Equal(header, []byte(armor.Header)) {
+		reader = armor.NewReader(buffered)
+	} else {
+		reader = buffered
+	}
+
+	result, err := age.Decrypt(reader, move.ageIdentities...)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "decrypting %s", RedactURL(source))
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Check that an output is encrypted only when it can be: file:// outputs are
+// encrypted when there are age recipients, which outputs named ".age" need.
+func (move *BasicMove) checkEncryptedOutput(outputURL string) error {
+	parsedURL, err := url.Parse(outputURL)
+	if err != nil || parsedURL.Scheme != "file" {
+		if len(move.ageRecipients) > 0 {
+			return wraperror.Errorf(errForPackage, "only file:// outputs can be encrypted: %s", RedactURL(outputURL))
+		}
+
+		return nil
+	}
+
+	if len(move.ageRecipients) == 0 && strings.HasSuffix(parsedURL.Path, ageSuffix) {
+		return wraperror.Errorf(errForPackage, "please define age recipients to encrypt %s", parsedURL.Path)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// A writer of an output file, encrypting what is written to the
+// AgeRecipients.  Closing it writes the end of the encrypted file but leaves
+// the file open.
+func (move *BasicMove) encryptWriter(fileName string, writer io.Writer) (io.WriteCloser, error) {
+	if len(move.ageRecipients) == 0 {
+		return nopWriteCloser{writer}, nil
+	}
+
+	result, err := age.Encrypt(writer, move.ageRecipients...)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "encrypting %s", fileName)
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Check the age recipients and identities, keeping them for encrypting and
+// decrypting.
+func (move *BasicMove) validateEncryption() error {
+	move.ageRecipients = nil
+	move.ageIdentities = nil
+
+	recipients := strings.Join(move.AgeRecipients, "\n")
+
+	if move.AgeRecipientsFile != "" {
+		content, err := os.ReadFile(filepath.Clean(move.AgeRecipientsFile))
+		if err != nil {
+			return wraperror.Errorf(err, "reading age recipients file %s", move.AgeRecipientsFile)
+		}
+
+		recipients += "\n" + string(content)
+	}
+
+	if strings.TrimSpace(recipients) != "" {
+		parsed, err := age.ParseRecipients(strings.NewReader(recipients))
+		if err != nil {
+			return wraperror.Errorf(err, "invalid age recipients")
+		}
+
+		move.ageRecipients = parsed
+	}
+
+	// Encrypted files are complete only once closed, so their records cannot
+	// be acknowledged as they are written.
+	if len(move.ageRecipients) > 0 && move.isAcknowledging() {
+		return wraperror.Errorf(errForPackage, "encrypted outputs cannot be written while following inputs or watching a directory")
+	}
+
+	if move.AgeIdentityFile != "" {
+		file, err := os.Open(filepath.Clean(move.AgeIdentityFile))
+		if err != nil {
+			return wraperror.Errorf(err, "os.Open")
+		}
+
+		defer file.Close()
+
+		parsed, err := age.ParseIdentities(file)
+		if err != nil {
+			return wraperror.Errorf(err, "invalid age identity file %s", move.AgeIdentityFile)
+		}
+
+		move.ageIdentities = parsed
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The path of a resource URL, which names its format.
+func resourcePath(resourceURL string) string {
+	parsedURL, err := url.Parse(resourceURL)
+	if err != nil {
+		return resourceURL
+	}
+
+	return parsedURL.Path
+}
@@ -0,0 +1,220 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test encrypted files
+// ----------------------------------------------------------------------------
+
+// Outputs encrypted to recipients are read back with their identity.
+func TestBasicMove_Move_encryptedRoundTrip(test *testing.T) {
+	dir := test.TempDir()
+	identity, identityFile := createAgeIdentity(test, dir)
+
+	recipientsFile := filepath.Join(dir, "recipients.txt")
+	require.NoError(test, os.WriteFile(recipientsFile, []byte("# readers\n"+identity.Recipient().String()+"\n"), 0o600))
+
+	testCases := []struct {
+		name  string
+		mover move.BasicMove
+		file  string
+	}{
+		{
+			name:  "JSON-Lines",
+			mover: move.BasicMove{AgeRecipients: []string{identity.Recipient().String()}},
+			file:  "records.jsonl.age",
+		},
+		{
+			name:  "GZIP",
+			mover: move.BasicMove{AgeRecipientsFile: recipientsFile},
+			file:  "records.jsonl.gz.age",
+		},
+		{
+			// Recipients encrypt outputs whatever their names.
+			name:  "without the age suffix",
+			mover: move.BasicMove{AgeRecipients: []string{identity.Recipient().String()}},
+			file:  "records.jsonl",
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			_, writer, cleanUp := mockStdout(test)
+			defer cleanUp()
+
+			inputFile, cleanUpTempFile := createTempDataFile(test, testGoodData, "jsonl")
+			defer cleanUpTempFile()
+
+			outputDir := test.TempDir()
+			encryptedFile := filepath.Join(outputDir, testCase.file)
+			decryptedFile := filepath.Join(outputDir, "decrypted.jsonl")
+
+			mover := testCase.mover
+			mover.InputURL = "file://" + inputFile
+			mover.OutputURL = "file://" + encryptedFile
+			require.NoError(test, mover.Move(test.Context()))
+
+			encrypted, err := os.ReadFile(encryptedFile)
+			require.NoError(test, err)
+			require.True(test, bytes.HasPrefix(encrypted, []byte("age-encryption.org/v1\n")))
+			require.NotContains(test, string(encrypted), "RECORD_ID")
+
+			inputURL := "file://" + encryptedFile
+			if filepath.Ext(encryptedFile) != ".age" {
+				inputURL += ".age"
+				require.NoError(test, os.Rename(encryptedFile, encryptedFile+".age"))
+			}
+
+			mover = move.BasicMove{
+				AgeIdentityFile: identityFile,
+				InputURL:        inputURL,
+				OutputURL:       "file://" + decryptedFile,
+			}
+			err = mover.Move(test.Context())
+
+			writer.Close()
+
+			require.NoError(test, err)
+			require.Equal(test, 12, countLines(test, decryptedFile))
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_armoredInput(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	dir := test.TempDir()
+	identity, identityFile := createAgeIdentity(test, dir)
+
+	var encrypted bytes.Buffer
+
+	armored := armor.NewWriter(&encrypted)
+	plaintext, err := age.Encrypt(armored, identity.Recipient())
+	require.NoError(test, err)
+
+	_, err = io.WriteString(plaintext, testGoodData)
+	require.NoError(test, err)
+	require.NoError(test, plaintext.Close())
+	require.NoError(test, armored.Close())
+
+	inputFile := filepath.Join(dir, "records.jsonl.age")
+	outputFile := filepath.Join(dir, "output.jsonl")
+	require.NoError(test, os.WriteFile(inputFile, encrypted.Bytes(), 0o600))
+
+	mover := &move.BasicMove{
+		AgeIdentityFile: identityFile,
+		InputURL:        "file://" + inputFile,
+		OutputURL:       "file://" + outputFile,
+	}
+	err = mover.Move(test.Context())
+
+	writer.Close()
+
+	require.NoError(test, err)
+	require.Equal(test, 12, countLines(test, outputFile))
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_encryptionErrors(test *testing.T) {
+	dir := test.TempDir()
+	identity, identityFile := createAgeIdentity(test, dir)
+
+	otherIdentity, err := age.GenerateX25519Identity()
+	require.NoError(test, err)
+
+	encryptedFile := filepath.Join(dir, "records.jsonl.age")
+	encrypted, err := os.Create(encryptedFile)
+	require.NoError(test, err)
+
+	plaintext, err := age.Encrypt(encrypted, otherIdentity.Recipient())
+	require.NoError(test, err)
+
+	_, err = io.WriteString(plaintext, testGoodData)
+	require.NoError(test, err)
+	require.NoError(test, plaintext.Close())
+	require.NoError(test, encrypted.Close())
+
+	recipient := identity.Recipient().String()
+
+	testCases := []struct {
+		name  string
+		mover move.BasicMove
+	}{
+		{
+			name:  "encrypted output without recipients",
+			mover: move.BasicMove{OutputURL: "file://" + filepath.Join(dir, "output.jsonl.age")},
+		},
+		{
+			name:  "encrypted queue output",
+			mover: move.BasicMove{AgeRecipients: []string{recipient}, OutputURL: "amqp://localhost:5672/?queue-name=records"},
+		},
+		{
+			name:  "invalid recipient",
+			mover: move.BasicMove{AgeRecipients: []string{"age1invalid"}, OutputURL: "file://" + filepath.Join(dir, "output.jsonl")},
+		},
+		{
+			name:  "encrypted input without identity",
+			mover: move.BasicMove{InputURL: "file://" + encryptedFile, OutputURL: "file://" + filepath.Join(dir, "a.jsonl")},
+		},
+		{
+			name: "encrypted input for another identity",
+			mover: move.BasicMove{
+				AgeIdentityFile: identityFile,
+				InputURL:        "file://" + encryptedFile,
+				OutputURL:       "file://" + filepath.Join(dir, "b.jsonl"),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			_, writer, cleanUp := mockStdout(test)
+			defer cleanUp()
+
+			inputFile, cleanUpTempFile := createTempDataFile(test, testGoodData, "jsonl")
+			defer cleanUpTempFile()
+
+			mover := testCase.mover
+			if mover.InputURL == "" {
+				mover.InputURL = "file://" + inputFile
+			}
+
+			err := mover.Move(test.Context())
+			writer.Close()
+
+			require.Error(test, err)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Create an age identity, returning it and the file holding it.
+func createAgeIdentity(t *testing.T, dir string) (*age.X25519Identity, string) {
+	t.Helper()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	identityFile := filepath.Join(dir, "identity.txt")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0o600))
+
+	return identity, identityFile
+}
@@ -0,0 +1,192 @@
+package move
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// An Envelope wraps a record written with OutputEnvelope set to
+// OutputEnvelopeJSON, so that consumers can trace it back to where it was
+// read.  Its lowercase keys keep it apart from records, whose attributes are
+// uppercase.
+type Envelope struct {
+	// The original record.
+	Body json.RawMessage `json:"body"`
+	// SHA-256 of the original record, in hex.
+	ContentHash string    `json:"content_hash"`
+	IngestedAt  time.Time `json:"ingested_at"`
+	Line        int       `json:"line"`
+	Operation   string    `json:"operation,omitempty"`
+	// The CoreInstanceName of the run that moved the record.
+	RunID  string `json:"run_id"`
+	Source string `json:"source"`
+}
+
+// The keys every Envelope has; operation is left out when not tracked.
+var envelopeKeys = []string{"body", "content_hash", "ingested_at", "line", "run_id", "source"}
+
+// Formats of the records written.
+const (
+	// Records as they were read.  The default.
+	OutputEnvelopeNone = "none"
+	// Records wrapped in an Envelope.
+	OutputEnvelopeJSON = "json"
+)
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// The message written for a record: the record itself or, with the JSON
+// envelope, the record wrapped in an Envelope.
+func (move *BasicMove) messageFor(record queues.Record) string {
+	szRecord, isSzRecord := record.(*SzRecord)
+	if !isSzRecord || !strings.EqualFold(move.OutputEnvelope, OutputEnvelopeJSON) {
+		return record.GetMessage()
+	}
+
+	// The body is compacted, as it is when marshaled, so that the hash is
+	// that of the record read back from the envelope.  Bodies that are not
+	// JSON, when validation is skipped, are kept as strings.
+	var body bytes.Buffer
+
+	err := json.Compact(&body, []byte(szRecord.Body))
+	if err != nil {
+		body.Reset()
+		body.WriteString(szRecord.Body)
+	}
+
+	envelope := Envelope{
+		Body:        body.Bytes(),
+		ContentHash: sha256Hex(body.Bytes()),
+		IngestedAt:  szRecord.IngestedAt,
+		Line:        szRecord.ID,
+		Operation:   szRecord.Operation,
+		RunID:       move.RunID,
+		Source:      sourceURI(szRecord.Source),
+	}
+
+	if err != nil {
+		envelope.Body, _ = json.Marshal(szRecord.Body)
+	}
+
+	// Without HTML escaping, the body is written as hashed.
+	var message bytes.Buffer
+
+	encoder := json.NewEncoder(&message)
+	encoder.SetEscapeHTML(false)
+
+	err = encoder.Encode(envelope)
+	if err != nil {
+		return record.GetMessage()
+	}
+
+	return strings.TrimSuffix(message.String(), "\n")
+}
+
+// ----------------------------------------------------------------------------
+
+// Check the output envelope.
+func (move *BasicMove) validateOutputEnvelope() error {
+	switch strings.ToLower(move.OutputEnvelope) {
+	case "", OutputEnvelopeNone, OutputEnvelopeJSON:
+		return nil
+	default:
+		return wraperror.Errorf(errForPackage, "unknown output envelope: %s", move.OutputEnvelope)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The URI of the input a record was read from, with credentials redacted.
+// Files are given by their path.
+func sourceURI(source string) string {
+	if source == "stdin" || strings.Contains(source, "://") {
+		return RedactURL(source)
+	}
+
+	path, err := filepath.Abs(source)
+	if err != nil {
+		path = source
+	}
+
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// ----------------------------------------------------------------------------
+
+// The record in a line read and the operation it carries, which are the
+// line itself and no operation unless it holds an Envelope, so that records
+// written with the JSON envelope are read as they were before.  Only lines
+// with exactly the keys of an Envelope, whose content hash matches its body,
+// are taken for one; records that merely look alike are left as they are.
+func unwrapEnvelope(line string) (string, string) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") || !strings.Contains(trimmed, `"content_hash"`) {
+		return line, ""
+	}
+
+	var keys map[string]json.RawMessage
+
+	err := json.Unmarshal([]byte(trimmed), &keys)
+	if err != nil {
+		return line, ""
+	}
+
+	for _, key := range envelopeKeys {
+		if _, isPresent := keys[key]; !isPresent {
+			return line, ""
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.DisallowUnknownFields()
+
+	var envelope Envelope
+
+	err = decoder.Decode(&envelope)
+	if err != nil || len(envelope.Body) == 0 {
+		return line, ""
+	}
+
+	var compacted bytes.Buffer
+
+	err = json.Compact(&compacted, envelope.Body)
+	if err == nil && sha256Hex(compacted.Bytes()) == envelope.ContentHash {
+		return compacted.String(), envelope.Operation
+	}
+
+	// Bodies that are not JSON were kept as strings and hashed as read.
+	var body string
+
+	err = json.Unmarshal(envelope.Body, &body)
+	if err == nil && sha256Hex([]byte(body)) == envelope.ContentHash {
+		return body, envelope.Operation
+	}
+
+	return line, ""
+}
+
+// ----------------------------------------------------------------------------
+
+// The SHA-256 of content, in hex.
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,180 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test the output envelope
+// ----------------------------------------------------------------------------
+
+// Records written with the JSON envelope are read back as they were.
+func TestBasicMove_Move_envelopeRoundTrip(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputFile, cleanUpTempFile := createTempDataFile(test, testGoodData, "jsonl")
+	defer cleanUpTempFile()
+
+	outputDir := test.TempDir()
+	envelopeFile := filepath.Join(outputDir, "envelope.jsonl")
+	roundTripFile := filepath.Join(outputDir, "round-trip.jsonl")
+
+	mover := &move.BasicMove{
+		InputURL:       "file://" + inputFile,
+		Operation:      []string{"replace"},
+		OutputEnvelope: move.OutputEnvelopeJSON,
+		OutputURL:      "file://" + envelopeFile,
+		RunID:          "move-test",
+	}
+	require.NoError(test, mover.Move(test.Context()))
+
+	mover = &move.BasicMove{
+		InputURL:  "file://" + envelopeFile,
+		OutputURL: "file://" + roundTripFile,
+	}
+	err := mover.Move(test.Context())
+
+	writer.Close()
+
+	require.NoError(test, err)
+
+	expected := strings.Split(strings.TrimSpace(testGoodData), "\n")
+	envelopes := readLines(test, envelopeFile)
+	roundTrip := readLines(test, roundTripFile)
+
+	require.Len(test, envelopes, len(expected))
+	require.Len(test, roundTrip, len(expected))
+
+	for index, line := range envelopes {
+		var envelope move.Envelope
+		require.NoError(test, json.Unmarshal([]byte(line), &envelope))
+
+		sum := sha256.Sum256(envelope.Body)
+
+		require.Equal(test, "file://"+inputFile, envelope.Source)
+		require.Equal(test, index+1, envelope.Line)
+		require.Equal(test, "move-test", envelope.RunID)
+		require.Equal(test, "replace", envelope.Operation)
+		require.Equal(test, hex.EncodeToString(sum[:]), envelope.ContentHash)
+		require.False(test, envelope.IngestedAt.IsZero())
+		require.JSONEq(test, expected[index], string(envelope.Body))
+		require.JSONEq(test, expected[index], roundTrip[index])
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// The operation of records read from envelopes is carried through.
+func TestBasicMove_Move_envelopeOperation(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputFile, cleanUpTempFile := createTempDataFile(test, testGoodData, "jsonl")
+	defer cleanUpTempFile()
+
+	outputDir := test.TempDir()
+	envelopeFile := filepath.Join(outputDir, "envelope.jsonl")
+	reenvelopedFile := filepath.Join(outputDir, "re-enveloped.jsonl")
+
+	mover := &move.BasicMove{
+		InputURL:       "file://" + inputFile,
+		Operation:      []string{"delete"},
+		OutputEnvelope: move.OutputEnvelopeJSON,
+		OutputURL:      "file://" + envelopeFile,
+	}
+	require.NoError(test, mover.Move(test.Context()))
+
+	mover = &move.BasicMove{
+		InputURL:       "file://" + envelopeFile,
+		OutputEnvelope: move.OutputEnvelopeJSON,
+		OutputURL:      "file://" + reenvelopedFile,
+	}
+	err := mover.Move(test.Context())
+
+	writer.Close()
+
+	require.NoError(test, err)
+
+	expected := strings.Split(strings.TrimSpace(testGoodData), "\n")
+
+	for index, line := range readLines(test, reenvelopedFile) {
+		var envelope move.Envelope
+		require.NoError(test, json.Unmarshal([]byte(line), &envelope))
+		require.Equal(test, "delete", envelope.Operation)
+		require.JSONEq(test, expected[index], string(envelope.Body))
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Records that only look like envelopes are moved as they are.
+func TestBasicMove_Move_envelopeLookalike(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	body := `{"DATA_SOURCE":"TEST","RECORD_ID":"1"}`
+	sum := sha256.Sum256([]byte(body))
+	envelope := `"body":` + body + `,"content_hash":"` + hex.EncodeToString(sum[:]) +
+		`","ingested_at":"2026-10-18T09:30:00Z","line":1,"run_id":"move-test","source":"file:///records.jsonl"`
+	lookalikes := []string{
+		`{` + envelope + `,"DATA_SOURCE":"TEST","RECORD_ID":"2"}`,
+		`{"body":` + body + `,"content_hash":"not-the-hash","source":"file:///records.jsonl","DATA_SOURCE":"TEST","RECORD_ID":"3"}`,
+	}
+
+	// An envelope whose hash does not match is not unwrapped, and is then
+	// rejected as a record.
+	tampered := strings.Replace(`{`+envelope+`}`, hex.EncodeToString(sum[:]), strings.Repeat("0", 64), 1)
+	data := strings.Join(append(lookalikes, tampered, `{`+envelope+`}`), "\n") + "\n"
+
+	inputFile, cleanUpTempFile := createTempDataFile(test, data, "jsonl")
+	defer cleanUpTempFile()
+
+	outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+	mover := &move.BasicMove{
+		InputURL:  "file://" + inputFile,
+		OutputURL: "file://" + outputFile,
+	}
+	err := mover.Move(test.Context())
+
+	writer.Close()
+
+	require.NoError(test, err)
+	require.Equal(test, append(lookalikes, body), readLines(test, outputFile))
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_unknown_envelope(test *testing.T) {
+	mover := &move.BasicMove{
+		OutputEnvelope: "xml",
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func readLines(t *testing.T, fileName string) []string {
+	t.Helper()
+
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+
+	return strings.Split(strings.TrimSpace(string(content)), "\n")
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+
+package move
+
+import (
+	"os"
+	"syscall"
+)
+
+// The inode of a file, used to recognize a file that was replaced while
+// move was not running.
+func fileIdentity(info os.FileInfo) (uint64, bool) {
+	stat, isStat := info.Sys().(*syscall.Stat_t)
+	if !isStat {
+		return 0, false
+	}
+
+	return stat.Ino, true
+}
@@ -0,0 +1,15 @@
+//go:build windows
+
+package move
+
+import (
+	"os"
+)
+
+// File identities are not available from os.FileInfo on Windows; a replaced
+// file is only recognized by its size.
+func fileIdentity(info os.FileInfo) (uint64, bool) {
+	_ = info
+
+	return 0, false
+}
@@ -0,0 +1,623 @@
+package move
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// FilterExpression is a compiled --filter expression.  Expressions are
+// evaluated against the top level attributes of a record, for example:
+//
+//	DATA_SOURCE == "CUSTOMERS" && has(EMAIL_ADDRESS)
+//
+// The language supports string, number, true, false and null literals,
+// attribute names, parentheses, the operators == != < <= > >= && || ! and
+// the functions has, contains, startsWith, endsWith and matches.
+type FilterExpression struct {
+	expression string
+	root       filterNode
+}
+
+// A node of the expression tree, evaluated against a record's attributes.
+type filterNode interface {
+	eval(attributes map[string]any) any
+}
+
+type filterAttribute struct {
+	name string
+}
+
+type filterLiteral struct {
+	value any
+}
+
+type filterNot struct {
+	operand filterNode
+}
+
+type filterBinary struct {
+	left     filterNode
+	operator string
+	right    filterNode
+}
+
+type filterCall struct {
+	arguments []filterNode
+	name      string
+	pattern   *regexp.Regexp
+}
+
+type filterParser struct {
+	position int
+	tokens   []filterToken
+}
+
+type filterToken struct {
+	kind  int
+	text  string
+	value any
+}
+
+const (
+	filterTokenEnd = iota
+	filterTokenIdent
+	filterTokenLiteral
+	filterTokenOperator
+)
+
+// The number of arguments taken by each function.
+var filterFunctions = map[string]int{
+	"contains":   2,
+	"endsWith":   2,
+	"has":        1,
+	"matches":    2,
+	"startsWith": 2,
+}
+
+// Two character operators are listed first so they are matched first.
+var filterOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", ","}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+// ParseFilter compiles a --filter expression.
+func ParseFilter(expression string) (*FilterExpression, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "filter %q", expression)
+	}
+
+	parser := &filterParser{tokens: tokens}
+
+	root, err := parser.parseOr()
+	if err == nil && parser.peek().kind != filterTokenEnd {
+		err = wraperror.Errorf(errForPackage, "unexpected %q", parser.peek().text)
+	}
+
+	if err != nil {
+		return nil, wraperror.Errorf(err, "filter %q", expression)
+	}
+
+	return &FilterExpression{expression: expression, root: root}, nil
+}
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+// Match reports whether a JSON line satisfies the expression.
+func (filter *FilterExpression) Match(line string) (bool, error) {
+	attributes, err := parseRecordBody(line)
+	if err != nil {
+		return false, err
+	}
+
+	return filter.MatchAttributes(attributes), nil
+}
+
+// MatchAttributes reports whether a parsed record satisfies the expression.
+func (filter *FilterExpression) MatchAttributes(attributes map[string]any) bool {
+	return filter.root.eval(attributes) == true
+}
+
+// String returns the expression the filter was compiled from.
+func (filter *FilterExpression) String() string {
+	return filter.expression
+}
+
+// ----------------------------------------------------------------------------
+
+// Filtered returns the number of valid records that did not match --filter.
+func (move *BasicMove) Filtered() int64 {
+	return atomic.LoadInt64(&move.filtered)
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Check a valid record against the filter.  Reports whether the record should
+// still be moved.
+func (move *BasicMove) checkFilter(line string) (bool, error) {
+	if move.filter == nil {
+		return true, nil
+	}
+
+	matched, err := move.filter.Match(line)
+	if err != nil {
+		return false, err
+	}
+
+	if !matched {
+		atomic.AddInt64(&move.filtered, 1)
+	}
+
+	return matched, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Compile the filter, if there is one, and reset the count of records it did
+// not match for a run.  This must be done before any input is read, as inputs
+// are read concurrently.
+func (move *BasicMove) startFilter() error {
+	move.filter = nil
+	atomic.StoreInt64(&move.filtered, 0)
+
+	if move.Filter == "" {
+		return nil
+	}
+
+	filter, err := ParseFilter(move.Filter)
+	if err != nil {
+		return err
+	}
+
+	move.filter = filter
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+func (node *filterAttribute) eval(attributes map[string]any) any {
+	return attributes[node.name]
+}
+
+func (node *filterLiteral) eval(_ map[string]any) any {
+	return node.value
+}
+
+func (node *filterNot) eval(attributes map[string]any) any {
+	return node.operand.eval(attributes) != true
+}
+
+func (node *filterBinary) eval(attributes map[string]any) any {
+	left := node.left.eval(attributes)
+
+	// && and || short circuit.
+	switch node.operator {
+	case "&&":
+		return left == true && node.right.eval(attributes) == true
+	case "||":
+		return left == true || node.right.eval(attributes) == true
+	}
+
+	right := node.right.eval(attributes)
+
+	switch node.operator {
+	case "==":
+		return filterEqual(left, right)
+	case "!=":
+		return !filterEqual(left, right)
+	}
+
+	order, comparable := filterCompare(left, right)
+	if !comparable {
+		return false
+	}
+
+	switch node.operator {
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	default:
+		return order >= 0
+	}
+}
+
+func (node *filterCall) eval(attributes map[string]any) any {
+	value := node.arguments[0].eval(attributes)
+
+	if node.name == "has" {
+		return hasValue(value)
+	}
+
+	text, isString := filterString(value)
+	if !isString {
+		return false
+	}
+
+	if node.pattern != nil {
+		return node.pattern.MatchString(text)
+	}
+
+	argument, isString := filterString(node.arguments[1].eval(attributes))
+	if !isString {
+		return false
+	}
+
+	switch node.name {
+	case "contains":
+		return strings.Contains(text, argument)
+	case "endsWith":
+		return strings.HasSuffix(text, argument)
+	default:
+		return strings.HasPrefix(text, argument)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func (parser *filterParser) next() filterToken {
+	token := parser.peek()
+	if token.kind != filterTokenEnd {
+		parser.position++
+	}
+
+	return token
+}
+
+func (parser *filterParser) peek() filterToken {
+	if parser.position >= len(parser.tokens) {
+		return filterToken{kind: filterTokenEnd, text: "end of expression"}
+	}
+
+	return parser.tokens[parser.position]
+}
+
+func (parser *filterParser) accept(operator string) bool {
+	token := parser.peek()
+	if token.kind == filterTokenOperator && token.text == operator {
+		parser.position++
+
+		return true
+	}
+
+	return false
+}
+
+func (parser *filterParser) expect(operator string) error {
+	if !parser.accept(operator) {
+		return wraperror.Errorf(errForPackage, "expected %q, found %q", operator, parser.peek().text)
+	}
+
+	return nil
+}
+
+func (parser *filterParser) parseOr() (filterNode, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.accept("||") {
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &filterBinary{left: left, operator: "||", right: right}
+	}
+
+	return left, nil
+}
+
+func (parser *filterParser) parseAnd() (filterNode, error) {
+	left, err := parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.accept("&&") {
+		right, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &filterBinary{left: left, operator: "&&", right: right}
+	}
+
+	return left, nil
+}
+
+func (parser *filterParser) parseUnary() (filterNode, error) {
+	if parser.accept("!") {
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &filterNot{operand: operand}, nil
+	}
+
+	return parser.parseComparison()
+}
+
+func (parser *filterParser) parseComparison() (filterNode, error) {
+	left, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	token := parser.peek()
+	if token.kind != filterTokenOperator {
+		return left, nil
+	}
+
+	switch token.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		parser.next()
+
+		right, err := parser.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		return &filterBinary{left: left, operator: token.text, right: right}, nil
+	default:
+		return left, nil
+	}
+}
+
+func (parser *filterParser) parseOperand() (filterNode, error) {
+	token := parser.next()
+
+	switch token.kind {
+	case filterTokenLiteral:
+		return &filterLiteral{value: token.value}, nil
+	case filterTokenIdent:
+		if parser.accept("(") {
+			return parser.parseCall(token.text)
+		}
+
+		return &filterAttribute{name: token.text}, nil
+	case filterTokenOperator:
+		if token.text == "(" {
+			node, err := parser.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			return node, parser.expect(")")
+		}
+	}
+
+	return nil, wraperror.Errorf(errForPackage, "unexpected %q", token.text)
+}
+
+func (parser *filterParser) parseCall(name string) (filterNode, error) {
+	arity, isFunction := filterFunctions[name]
+	if !isFunction {
+		return nil, wraperror.Errorf(errForPackage, "unknown function %s", name)
+	}
+
+	node := &filterCall{name: name}
+
+	for index := range arity {
+		if index > 0 {
+			err := parser.expect(",")
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		argument, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		node.arguments = append(node.arguments, argument)
+	}
+
+	err := parser.expect(")")
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "has" {
+		if _, isAttribute := node.arguments[0].(*filterAttribute); !isAttribute {
+			return nil, wraperror.Errorf(errForPackage, "has takes an attribute name")
+		}
+	}
+
+	if name == "matches" {
+		literal, isLiteral := node.arguments[1].(*filterLiteral)
+
+		pattern, isString := "", false
+		if isLiteral {
+			pattern, isString = literal.value.(string)
+		}
+
+		if !isString {
+			return nil, wraperror.Errorf(errForPackage, "matches takes a string literal pattern")
+		}
+
+		node.pattern, err = regexp.Compile(pattern)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "regexp.Compile")
+		}
+	}
+
+	return node, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Split an expression into tokens.
+func tokenizeFilter(expression string) ([]filterToken, error) {
+	var result []filterToken
+
+	for position := 0; position < len(expression); {
+		character := rune(expression[position])
+		rest := expression[position:]
+
+		switch {
+		case unicode.IsSpace(character):
+			position++
+		case character == '"':
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, wraperror.Errorf(errForPackage, "unterminated string at offset %d", position)
+			}
+
+			value, _ := strconv.Unquote(quoted)
+			result = append(result, filterToken{kind: filterTokenLiteral, text: quoted, value: value})
+			position += len(quoted)
+		case character == '-' || unicode.IsDigit(character):
+			length := 1
+			for length < len(rest) && (unicode.IsDigit(rune(rest[length])) || rest[length] == '.') {
+				length++
+			}
+
+			value, err := strconv.ParseFloat(rest[:length], 64)
+			if err != nil {
+				return nil, wraperror.Errorf(errForPackage, "bad number %q at offset %d", rest[:length], position)
+			}
+
+			result = append(result, filterToken{kind: filterTokenLiteral, text: rest[:length], value: value})
+			position += length
+		case character == '_' || unicode.IsLetter(character):
+			length := 1
+			for length < len(rest) && isFilterIdentCharacter(rune(rest[length])) {
+				length++
+			}
+
+			result = append(result, identToken(rest[:length]))
+			position += length
+		default:
+			operator := filterOperatorPrefix(rest)
+			if operator == "" {
+				return nil, wraperror.Errorf(errForPackage, "unexpected %q at offset %d", character, position)
+			}
+
+			result = append(result, filterToken{kind: filterTokenOperator, text: operator})
+			position += len(operator)
+		}
+	}
+
+	return result, nil
+}
+
+// An identifier, or one of the keyword literals.
+func identToken(text string) filterToken {
+	switch text {
+	case "true":
+		return filterToken{kind: filterTokenLiteral, text: text, value: true}
+	case "false":
+		return filterToken{kind: filterTokenLiteral, text: text, value: false}
+	case "null":
+		return filterToken{kind: filterTokenLiteral, text: text, value: nil}
+	default:
+		return filterToken{kind: filterTokenIdent, text: text}
+	}
+}
+
+func isFilterIdentCharacter(character rune) bool {
+	return character == '_' || unicode.IsLetter(character) || unicode.IsDigit(character)
+}
+
+func filterOperatorPrefix(text string) string {
+	for _, operator := range filterOperators {
+		if strings.HasPrefix(text, operator) {
+			return operator
+		}
+	}
+
+	return ""
+}
+
+// Numbers are compared by value, whether they come from the record or the
+// expression; everything else must match exactly.
+func filterEqual(left any, right any) bool {
+	leftNumber, leftIsNumber := filterNumber(left)
+	rightNumber, rightIsNumber := filterNumber(right)
+
+	if leftIsNumber || rightIsNumber {
+		return leftIsNumber && rightIsNumber && leftNumber == rightNumber
+	}
+
+	switch left.(type) {
+	case nil, bool, string:
+		return left == right
+	default:
+		return false
+	}
+}
+
+// Order two numbers or two strings.  Other values are not comparable.
+func filterCompare(left any, right any) (int, bool) {
+	leftNumber, leftIsNumber := filterNumber(left)
+	rightNumber, rightIsNumber := filterNumber(right)
+
+	if leftIsNumber && rightIsNumber {
+		switch {
+		case leftNumber < rightNumber:
+			return -1, true
+		case leftNumber > rightNumber:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	leftString, leftIsString := left.(string)
+	rightString, rightIsString := right.(string)
+
+	if leftIsString && rightIsString {
+		return strings.Compare(leftString, rightString), true
+	}
+
+	return 0, false
+}
+
+func filterNumber(value any) (float64, bool) {
+	switch typedValue := value.(type) {
+	case float64:
+		return typedValue, true
+	case json.Number:
+		result, err := typedValue.Float64()
+
+		return result, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Strings and numbers can be searched as text.
+func filterString(value any) (string, bool) {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, true
+	case json.Number:
+		return typedValue.String(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,135 @@
+package move_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterExpression_Match(test *testing.T) {
+	line := `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "7", "AGE": 42, "EMAIL_ADDRESS": "bob@example.com", "ACTIVE": true}`
+
+	testCases := []struct {
+		expression string
+		expected   bool
+	}{
+		{expression: `DATA_SOURCE == "CUSTOMERS" && has(EMAIL_ADDRESS)`, expected: true},
+		{expression: `DATA_SOURCE == "CUSTOMERS" && has(PHONE_NUMBER)`, expected: false},
+		{expression: `DATA_SOURCE == "VENDORS" || RECORD_ID == "7"`, expected: true},
+		{expression: `!(DATA_SOURCE != "CUSTOMERS")`, expected: true},
+		{expression: `AGE >= 42 && AGE < 43.5`, expected: true},
+		{expression: `AGE > "40"`, expected: false},
+		{expression: `AGE == 42.0`, expected: true},
+		{expression: `RECORD_ID == 7`, expected: false},
+		{expression: `ACTIVE`, expected: true},
+		{expression: `ACTIVE == false`, expected: false},
+		{expression: `MISSING == null`, expected: true},
+		{expression: `contains(EMAIL_ADDRESS, "@example")`, expected: true},
+		{expression: `startsWith(DATA_SOURCE, "CUST") && endsWith(DATA_SOURCE, "MERS")`, expected: true},
+		{expression: `matches(EMAIL_ADDRESS, "^[a-z]+@")`, expected: true},
+		{expression: `matches(AGE, "^4")`, expected: true},
+		{expression: `contains(MISSING, "x")`, expected: false},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.expression, func(test *testing.T) {
+			filter, err := move.ParseFilter(testCase.expression)
+			require.NoError(test, err)
+
+			actual, err := filter.Match(line)
+			require.NoError(test, err)
+			require.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestParseFilter_errors(test *testing.T) {
+	testCases := []string{
+		``,
+		`DATA_SOURCE ==`,
+		`DATA_SOURCE == "CUSTOMERS`,
+		`(DATA_SOURCE == "CUSTOMERS"`,
+		`DATA_SOURCE == "A" "B"`,
+		`unknown(DATA_SOURCE)`,
+		`has("DATA_SOURCE")`,
+		`has(DATA_SOURCE, RECORD_ID)`,
+		`matches(DATA_SOURCE, RECORD_ID)`,
+		`matches(DATA_SOURCE, "(")`,
+		`DATA_SOURCE = "A"`,
+		`AGE > 1.2.3`,
+	}
+	for _, expression := range testCases {
+		test.Run(expression, func(test *testing.T) {
+			_, err := move.ParseFilter(expression)
+			require.Error(test, err)
+		})
+	}
+}
+
+func TestBasicMove_processJSONL_filter(test *testing.T) {
+	data := `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1", "EMAIL_ADDRESS": "a@example.com"}
+{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "2"}
+{"DATA_SOURCE": "VENDORS", "RECORD_ID": "3", "EMAIL_ADDRESS": "b@example.com"}
+{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "4", "EMAIL_ADDRESS": "c@example.com"}
+{"DATA_SOURCE": "CUSTOMERS"}
+`
+	recordchan := make(chan queues.Record, 5)
+
+	mover := &move.BasicMove{
+		Filter: `DATA_SOURCE == "CUSTOMERS" && has(EMAIL_ADDRESS)`,
+	}
+	mover.ProcessJSONL("test.jsonl", strings.NewReader(data), recordchan)
+
+	var actual []string
+	for record := range recordchan {
+		actual = append(actual, record.GetMessageID())
+	}
+
+	// The invalid record is not counted as filtered.
+	require.Equal(test, []string{"test.jsonl-1", "test.jsonl-4"}, actual)
+	require.Equal(test, int64(2), mover.Filtered())
+}
+
+// Each run uses the filter as it is then and counts only its own records.
+func TestBasicMove_processJSONL_filter_runs(test *testing.T) {
+	data := `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1"}
+{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "2"}
+{"DATA_SOURCE": "VENDORS", "RECORD_ID": "3"}
+`
+
+	mover := &move.BasicMove{}
+
+	for _, testCase := range []struct {
+		filter           string
+		expected         []string
+		expectedFiltered int64
+	}{
+		{filter: `DATA_SOURCE == "CUSTOMERS"`, expected: []string{"test.jsonl-1", "test.jsonl-2"}, expectedFiltered: 1},
+		{filter: `DATA_SOURCE == "VENDORS"`, expected: []string{"test.jsonl-3"}, expectedFiltered: 2},
+		{expected: []string{"test.jsonl-1", "test.jsonl-2", "test.jsonl-3"}},
+	} {
+		recordchan := make(chan queues.Record, 5)
+
+		mover.Filter = testCase.filter
+		mover.ProcessJSONL("test.jsonl", strings.NewReader(data), recordchan)
+
+		var actual []string
+		for record := range recordchan {
+			actual = append(actual, record.GetMessageID())
+		}
+
+		require.Equal(test, testCase.expected, actual)
+		require.Equal(test, testCase.expectedFiltered, mover.Filtered())
+	}
+}
+
+func TestBasicMove_Move_bad_filter(test *testing.T) {
+	mover := &move.BasicMove{
+		Filter: `DATA_SOURCE ==`,
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+}
@@ -0,0 +1,349 @@
+package move
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The position reached in a followed file, persisted so that following
+// resumes there after a restart.
+type followState struct {
+	FileID uint64 `json:"fileId"`
+	Line   int    `json:"line"`
+	Offset int64  `json:"offset"`
+}
+
+// A followed file: the open file, the position read and the partial line
+// after the last newline, which is not processed until it is complete.
+type followedFile struct {
+	file    *os.File
+	info    os.FileInfo
+	partial string
+	reader  *bufio.Reader
+	state   followState
+}
+
+const (
+	defaultFollowPollInterval = time.Second
+	followSaveEvery           = 1000
+	// How long to wait, once stopped, for the writer to acknowledge the
+	// records read so that their position can be saved.
+	followStopGrace = 5 * time.Second
+)
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Read a JSONL file like tail -F: lines appended to the file are read until
+// ctx is cancelled.  A file that is truncated is read again from the start
+// and a file that is replaced, e.g. by log rotation, is read to its end
+// before the new file is read.  The position reached is saved to a state
+// file, once the writer has acknowledged the records read up to it, so that
+// a restart reads again only the lines whose records were not delivered.
+func (move *BasicMove) followJSONLFile(
+	ctx context.Context,
+	fileName string,
+	recordchan chan queues.Record,
+	result *InputResult,
+) error {
+	statePath := move.followStatePath(fileName)
+	state := loadFollowState(statePath)
+
+	followed, err := openFollowedFile(fileName, state)
+	if err != nil {
+		return err
+	}
+
+	defer func() { followed.close() }()
+
+	pollInterval := move.FollowPollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultFollowPollInterval
+	}
+
+	result.delivery = newDelivery()
+
+	for {
+		more, err := move.followLines(ctx, fileName, followed, recordchan, result)
+		if err != nil || !more {
+			return errors.Join(err, move.followCheckpoint(ctx, statePath, followed.state, result))
+		}
+
+		err = move.followCheckpoint(ctx, statePath, followed.state, result)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+
+		followed, err = move.followChanges(ctx, fileName, followed, recordchan, result)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Process the complete lines available in a followed file.  Reports whether
+// following should continue.
+func (move *BasicMove) followLines(
+	ctx context.Context,
+	fileName string,
+	followed *followedFile,
+	recordchan chan queues.Record,
+	result *InputResult,
+) (bool, error) {
+	for ctx.Err() == nil {
+		text, err := followed.reader.ReadString('\n')
+		followed.partial += text
+
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+
+		if err != nil {
+			return false, wraperror.Errorf(err, "ReadString")
+		}
+
+		line := followed.partial
+		followed.partial = ""
+		followed.state.Offset += int64(len(line))
+		followed.state.Line++
+
+		if !move.processIteration(ctx, fileName, followed.state.Line, line, recordchan, result) {
+			return false, nil
+		}
+
+		if followed.state.Line%followSaveEvery == 0 {
+			err = move.followCheckpoint(ctx, move.followStatePath(fileName), followed.state, result)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Check a followed file for truncation and replacement, returning the file to
+// continue following.
+func (move *BasicMove) followChanges(
+	ctx context.Context,
+	fileName string,
+	followed *followedFile,
+	recordchan chan queues.Record,
+	result *InputResult,
+) (*followedFile, error) {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		// Between rotating the old file away and creating the new one.
+		return followed, nil //nolint:nilerr
+	}
+
+	if !os.SameFile(info, followed.info) {
+		// Finish the old file first, including a last line without a
+		// newline, then start the new one from the start.
+		_, err = move.followLines(ctx, fileName, followed, recordchan, result)
+		if err != nil {
+			return followed, err
+		}
+
+		if followed.partial != "" && ctx.Err() == nil {
+			followed.state.Offset += int64(len(followed.partial))
+			followed.state.Line++
+			move.processIteration(ctx, fileName, followed.state.Line, followed.partial, recordchan, result)
+			followed.partial = ""
+		}
+
+		move.log(2008, fileName)
+
+		newFollowed, err := openFollowedFile(fileName, followState{Line: followed.state.Line})
+		if err != nil {
+			return followed, err
+		}
+
+		followed.close()
+
+		return newFollowed, nil
+	}
+
+	if info.Size() < followed.state.Offset+int64(len(followed.partial)) {
+		move.log(2009, fileName)
+
+		_, err = followed.file.Seek(0, io.SeekStart)
+		if err != nil {
+			return followed, wraperror.Errorf(err, "Seek")
+		}
+
+		followed.reader.Reset(followed.file)
+		followed.partial = ""
+		followed.state.Offset = 0
+	}
+
+	return followed, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Save the position in a followed file once the writer has acknowledged the
+// records read up to it.  Once stopped, the writer is given a grace period to
+// do so; if it does not, or it has failed, the position is not saved and the
+// lines are read again by the next run.
+func (move *BasicMove) followCheckpoint(
+	ctx context.Context,
+	statePath string,
+	state followState,
+	result *InputResult,
+) error {
+	delivery := result.delivery
+	result.delivery = newDelivery()
+
+	delivery.seal()
+
+	waitCtx := ctx
+	if isStopped(ctx) {
+		var cancel context.CancelFunc
+
+		waitCtx, cancel = context.WithTimeout(context.WithoutCancel(ctx), followStopGrace)
+		defer cancel()
+	}
+
+	_, err := delivery.wait(waitCtx)
+
+	switch {
+	case waitCtx.Err() != nil:
+		return nil
+	case err != nil:
+		return err
+	default:
+		return saveFollowState(statePath, state)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// The file the position in a followed file is saved to.
+func (move *BasicMove) followStatePath(fileName string) string {
+	directory := move.FollowStateDirectory
+	if directory == "" {
+		directory = filepath.Dir(fileName)
+	}
+
+	return filepath.Join(directory, "."+filepath.Base(fileName)+".move-offset")
+}
+
+// ----------------------------------------------------------------------------
+
+func (followed *followedFile) close() {
+	_ = followed.file.Close()
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Open a file to follow, resuming at the saved position when it is still
+// the same file and has not been truncated.
+func openFollowedFile(fileName string, state followState) (*followedFile, error) {
+	file, err := os.Open(filepath.Clean(fileName))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "os.Open")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return nil, wraperror.Errorf(err, "file.Stat")
+	}
+
+	fileID, hasFileID := fileIdentity(info)
+
+	if (hasFileID && state.FileID != fileID) || info.Size() < state.Offset {
+		state.Offset = 0
+	}
+
+	state.FileID = fileID
+
+	_, err = file.Seek(state.Offset, io.SeekStart)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, wraperror.Errorf(err, "Seek")
+	}
+
+	return &followedFile{
+		file:   file,
+		info:   info,
+		reader: bufio.NewReader(file),
+		state:  state,
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Load a saved position.  A missing or unreadable state file starts from
+// the beginning of the file.
+func loadFollowState(statePath string) followState {
+	var result followState
+
+	content, err := os.ReadFile(filepath.Clean(statePath))
+	if err != nil {
+		return result
+	}
+
+	err = json.Unmarshal(content, &result)
+	if err != nil {
+		return followState{}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+// Save a position, replacing the state file atomically.
+func saveFollowState(statePath string, state followState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return wraperror.Errorf(err, "json.Marshal")
+	}
+
+	temporary := statePath + ".tmp"
+
+	err = os.WriteFile(temporary, content, 0o600) //nolint:mnd
+	if err != nil {
+		return wraperror.Errorf(err, "os.WriteFile")
+	}
+
+	return wraperror.Errorf(os.Rename(temporary, statePath), "os.Rename")
+}
+
+// ----------------------------------------------------------------------------
+
+// Report whether a file input name is one that can be followed.
+func isFollowable(fileName string, fileType string) bool {
+	return strings.HasSuffix(fileName, "jsonl") || strings.EqualFold(fileType, JSONL)
+}
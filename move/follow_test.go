@@ -0,0 +1,199 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test following a growing file
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_follow(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputDir := test.TempDir()
+	inputFile := filepath.Join(inputDir, "events.jsonl")
+
+	// The third line is still being written.
+	writeFile(test, inputFile, followRecord(1)+followRecord(2)+`{"DATA_SOURCE": "TEST", `)
+
+	collector := startFollowing(test, inputFile)
+
+	collector.waitFor(test, "1", "2")
+
+	// Lines appended to the file.
+	appendFile(test, inputFile, `"RECORD_ID": "3"}`+"\n"+followRecord(4))
+	collector.waitFor(test, "1", "2", "3", "4")
+
+	// A truncated file is read from the start.
+	writeFile(test, inputFile, followRecord(5))
+	collector.waitFor(test, "1", "2", "3", "4", "5")
+
+	// A rotated file is read to its end before the new file is read.
+	require.NoError(test, os.Rename(inputFile, inputFile+".1"))
+	appendFile(test, inputFile+".1", followRecord(6))
+	writeFile(test, inputFile, followRecord(7))
+	collector.waitFor(test, "1", "2", "3", "4", "5", "6", "7")
+
+	collector.stop(test)
+
+	// A restart resumes after the last line read.
+	appendFile(test, inputFile, followRecord(8))
+
+	collector = startFollowing(test, inputFile)
+	collector.waitFor(test, "8")
+	collector.stop(test)
+
+	writer.Close()
+}
+
+// The last line of a rotated file is moved even without a newline.
+func TestBasicMove_Move_follow_rotated_partial_line(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputFile := filepath.Join(test.TempDir(), "events.jsonl")
+	writeFile(test, inputFile, followRecord(1)+`{"DATA_SOURCE": "TEST", "RECORD_ID": "2"}`)
+
+	collector := startFollowing(test, inputFile)
+	collector.waitFor(test, "1")
+
+	require.NoError(test, os.Rename(inputFile, inputFile+".1"))
+	writeFile(test, inputFile, followRecord(3))
+	collector.waitFor(test, "1", "2", "3")
+	collector.stop(test)
+
+	writer.Close()
+}
+
+// The position is not saved for records the writer failed to deliver.
+func TestBasicMove_Move_follow_writer_fails(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	queue := startFakeSQS(test)
+	queue.rejectAll = true
+	queue.senderFault = true
+
+	inputDir := test.TempDir()
+	inputFile := filepath.Join(inputDir, "events.jsonl")
+	writeFile(test, inputFile, followRecord(1)+followRecord(2))
+
+	mover := &move.BasicMove{
+		Follow:             true,
+		FollowPollInterval: 10 * time.Millisecond,
+		InputURL:           "file://" + inputFile,
+		OutputURL:          "sqs://lookup?queue-name=records",
+	}
+
+	err := mover.Move(test.Context())
+	writer.Close()
+	require.Error(test, err)
+	require.NoFileExists(test, filepath.Join(inputDir, ".events.jsonl.move-offset"))
+}
+
+func TestBasicMove_Move_follow_not_a_file(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputFile, cleanUpTempFile := createTempGZIPDataFile(test, testGoodData)
+	test.Cleanup(cleanUpTempFile)
+
+	mover := &move.BasicMove{
+		Follow:    true,
+		InputURL:  "file://" + inputFile,
+		OutputURL: "file://" + filepath.Join(test.TempDir(), "output.jsonl"),
+	}
+
+	err := mover.Move(test.Context())
+	writer.Close()
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Collects the RECORD_IDs of the records a following Move reads.
+type followCollector struct {
+	cancel    context.CancelFunc
+	done      chan error
+	mutex     sync.Mutex
+	recordIDs []string
+}
+
+func startFollowing(t *testing.T, inputFile string) *followCollector {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	collector := &followCollector{cancel: cancel, done: make(chan error)}
+
+	mover := &move.BasicMove{
+		Follow:             true,
+		FollowPollInterval: 10 * time.Millisecond,
+		InputURL:           "file://" + inputFile,
+		OutputURL:          "file://" + filepath.Join(t.TempDir(), "output.jsonl"),
+	}
+	mover.AddHook("collect", func(_ context.Context, record *move.SzRecord) ([]*move.SzRecord, error) {
+		recordID, _ := decodeAttributes(t, record.Body)["RECORD_ID"].(string)
+
+		collector.mutex.Lock()
+		collector.recordIDs = append(collector.recordIDs, recordID)
+		collector.mutex.Unlock()
+
+		return []*move.SzRecord{record}, nil
+	})
+
+	go func() {
+		collector.done <- mover.Move(ctx)
+	}()
+
+	return collector
+}
+
+func (collector *followCollector) waitFor(t *testing.T, recordIDs ...string) {
+	t.Helper()
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		collector.mutex.Lock()
+		defer collector.mutex.Unlock()
+
+		assert.Equal(c, recordIDs, collector.recordIDs)
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func (collector *followCollector) stop(t *testing.T) {
+	t.Helper()
+
+	collector.cancel()
+	require.NoError(t, <-collector.done)
+}
+
+func followRecord(recordID int) string {
+	return fmt.Sprintf(`{"DATA_SOURCE": "TEST", "RECORD_ID": "%d"}`+"\n", recordID)
+}
+
+func appendFile(t *testing.T, fileName string, content string) {
+	t.Helper()
+
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
@@ -0,0 +1,117 @@
+package move
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// RecordHook inspects or alters a record after it has been validated and
+// before it is written.  It returns the records to move in its place:
+//   - none, to drop the record.
+//   - the record, possibly modified.
+//   - several records, to split the record.
+//
+// An error rejects the record; the error is logged as the reason.  A nil
+// record returned is rejected the same way.  Records returned by a hook are
+// passed to the next hook and validated again before they are written.  A
+// hook that splits records should give each its own ID so their message IDs
+// differ.
+//
+// Inputs are read InputParallelism at a time, so a hook may be called from
+// several goroutines at once and must be safe for concurrent use.
+type RecordHook func(ctx context.Context, record *SzRecord) ([]*SzRecord, error)
+
+type registeredHook struct {
+	errors atomic.Int64
+	hook   RecordHook
+	name   string
+}
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+// AddHook registers a hook.  Hooks run in the order they are added.  The
+// name identifies the hook in logs and in HookErrors.
+func (move *BasicMove) AddHook(name string, hook RecordHook) {
+	move.hooks = append(move.hooks, &registeredHook{hook: hook, name: name})
+}
+
+// ----------------------------------------------------------------------------
+
+// HookErrors returns the number of records each hook has rejected.
+func (move *BasicMove) HookErrors() map[string]int64 {
+	result := make(map[string]int64, len(move.hooks))
+	for _, hook := range move.hooks {
+		result[hook.name] += hook.errors.Load()
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Pass a record through every hook, in order.  A record rejected by a hook is
+// logged and not passed to later hooks.  Returns the records left and the
+// numbers of records hooks rejected and dropped along the way.
+func (move *BasicMove) runHooks(ctx context.Context, record *SzRecord) ([]*SzRecord, int, int) {
+	var rejected, dropped int
+
+	records := []*SzRecord{record}
+
+	for _, hook := range move.hooks {
+		var next []*SzRecord
+
+		for _, input := range records {
+			output, err := hook.hook(ctx, input)
+			if err != nil {
+				hook.errors.Add(1)
+				move.log(3014, hook.name, input.ID, err)
+
+				rejected++
+
+				continue
+			}
+
+			if len(output) == 0 {
+				dropped++
+			}
+
+			for _, record := range output {
+				if record == nil {
+					hook.errors.Add(1)
+					move.log(3014, hook.name, input.ID, wraperror.Errorf(errForPackage, "returned a nil record"))
+
+					rejected++
+
+					continue
+				}
+
+				next = append(next, record)
+			}
+		}
+
+		records = next
+	}
+
+	return records, rejected, dropped
+}
+
+// ----------------------------------------------------------------------------
+
+// Log the records rejected by each hook.
+func (move *BasicMove) logHookErrors() {
+	for _, hook := range move.hooks {
+		if errors := hook.errors.Load(); errors > 0 {
+			move.log(2006, hook.name, errors)
+		}
+	}
+}
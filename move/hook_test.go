@@ -0,0 +1,154 @@
+package move_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestHook = errors.New("rejected by test")
+
+func TestBasicMove_processJSONL_hooks(test *testing.T) {
+	data := `{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "2"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "3"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "4"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "5"}
+`
+	recordchan := make(chan queues.Record, 10)
+
+	var order []string
+
+	mover := &move.BasicMove{}
+
+	// Drop record 1, reject record 2, split record 3, break record 5.
+	mover.AddHook("first", func(_ context.Context, record *move.SzRecord) ([]*move.SzRecord, error) {
+		order = append(order, "first")
+
+		switch record.ID {
+		case 1:
+			return nil, nil
+		case 2:
+			return nil, errTestHook
+		case 3:
+			copied := *record
+			copied.ID = 30
+			copied.Body = strings.Replace(record.Body, `"3"`, `"3-copy"`, 1)
+
+			return []*move.SzRecord{record, &copied}, nil
+		case 5:
+			record.Body = `{"DATA_SOURCE": "TEST"}`
+		}
+
+		return []*move.SzRecord{record}, nil
+	})
+
+	// Modify every record that reaches it.
+	mover.AddHook("second", func(_ context.Context, record *move.SzRecord) ([]*move.SzRecord, error) {
+		order = append(order, "second")
+
+		record.Body = strings.Replace(record.Body, `"TEST"`, `"HOOKED"`, 1)
+
+		return []*move.SzRecord{record}, nil
+	})
+
+	mover.ProcessJSONLContext(test.Context(), "test.jsonl", strings.NewReader(data), recordchan)
+
+	var actual []string
+	for record := range recordchan {
+		actual = append(actual, record.GetMessage())
+	}
+
+	require.Equal(
+		test,
+		[]string{
+			`{"DATA_SOURCE": "HOOKED", "RECORD_ID": "3"}`,
+			`{"DATA_SOURCE": "HOOKED", "RECORD_ID": "3-copy"}`,
+			`{"DATA_SOURCE": "HOOKED", "RECORD_ID": "4"}`,
+		},
+		actual,
+	)
+	require.Equal(test, map[string]int64{"first": 1, "second": 0}, mover.HookErrors())
+	require.Equal(test, []string{"first", "first", "first", "second", "second", "first", "second", "first", "second"}, order)
+}
+
+func TestBasicMove_processJSONL_hook_context(test *testing.T) {
+	type contextKey struct{}
+
+	ctx := context.WithValue(test.Context(), contextKey{}, "value")
+	recordchan := make(chan queues.Record, 1)
+
+	var actual any
+
+	mover := &move.BasicMove{}
+	mover.AddHook("context", func(ctx context.Context, record *move.SzRecord) ([]*move.SzRecord, error) {
+		actual = ctx.Value(contextKey{})
+
+		return []*move.SzRecord{record}, nil
+	})
+	mover.ProcessJSONLContext(ctx, "test.jsonl", strings.NewReader(`{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}`), recordchan)
+
+	require.Len(test, recordchan, 1)
+	require.Equal(test, "value", actual)
+}
+
+// ----------------------------------------------------------------------------
+
+// Hooks are called concurrently for inputs read in parallel, and nil records
+// they return are rejected.
+func TestBasicMove_Move_hook_inputs(test *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(testGoodData))
+	}))
+	defer server.Close()
+
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	var (
+		calls atomic.Int64
+		mutex sync.Mutex
+		seen  = map[string]int{}
+	)
+
+	mover := &move.BasicMove{
+		InputParallelism: 3,
+		InputURLs:        []string{server.URL + "/a.jsonl", server.URL + "/b.jsonl", server.URL + "/c.jsonl"},
+	}
+	mover.AddHook("concurrent", func(_ context.Context, record *move.SzRecord) ([]*move.SzRecord, error) {
+		calls.Add(1)
+
+		mutex.Lock()
+		seen[record.Source]++
+		mutex.Unlock()
+
+		if record.ID == 1 {
+			return []*move.SzRecord{nil, record}, nil
+		}
+
+		return []*move.SzRecord{record}, nil
+	})
+
+	err := mover.Move(test.Context())
+
+	writer.Close()
+
+	require.NoError(test, err)
+	require.Equal(test, int64(36), calls.Load())
+	require.Len(test, seen, 3)
+	require.Equal(test, map[string]int64{"concurrent": 3}, mover.HookErrors())
+
+	for _, result := range mover.InputResults() {
+		require.Equal(test, 12, result.Moved)
+		require.Equal(test, 1, result.Rejected)
+	}
+}
@@ -0,0 +1,113 @@
+package move
+
+import (
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// The client used for HTTP inputs, created on first use from the HTTP
+// options.
+func (move *BasicMove) getHTTPClient() (*http.Client, error) {
+	if move.httpClient != nil {
+		return move.httpClient, nil
+	}
+
+	header, err := parseHTTPHeaders(move.HTTPHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := move.HTTPTimeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.DialContext = (&net.Dialer{Timeout: timeout}).DialContext
+	transport.TLSHandshakeTimeout = timeout
+	transport.ResponseHeaderTimeout = timeout
+
+	transport.TLSClientConfig, err = newTLSConfig(move.HTTPCACertFile, move.HTTPClientCertFile, move.HTTPClientKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if move.HTTPProxy != "" {
+		proxyURL, err := url.Parse(move.HTTPProxy)
+		if err != nil {
+			return nil, wraperror.Errorf(errForPackage, "invalid HTTP proxy URL")
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	move.httpHeader = header
+	move.httpClient = &http.Client{Transport: transport}
+
+	return move.httpClient, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Add the configured headers and credentials to a request.  The bearer token
+// file is read for every request so that a rotated token is used.
+func (move *BasicMove) authorizeHTTPRequest(request *http.Request) error {
+	for name, values := range move.httpHeader {
+		request.Header[name] = values
+	}
+
+	token := move.HTTPBearerToken
+
+	if move.HTTPBearerTokenFile != "" {
+		content, err := readSecretFile(move.HTTPBearerTokenFile)
+		if err != nil {
+			return err
+		}
+
+		move.fileSecrets.add(content)
+
+		token = content
+	}
+
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if move.HTTPBasicAuthUser != "" {
+		request.SetBasicAuth(move.HTTPBasicAuthUser, move.HTTPBasicAuthPassword)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Parse headers given as "Name: value".  Values are left out of errors as
+// they often hold credentials.
+func parseHTTPHeaders(headers []string) (http.Header, error) {
+	result := http.Header{}
+
+	for _, header := range headers {
+		name, value, found := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+
+		if !found || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, wraperror.Errorf(errForPackage, "HTTP header not in the form \"Name: value\": %s", name)
+		}
+
+		result.Add(textproto.CanonicalMIMEHeaderKey(name), strings.TrimSpace(value))
+	}
+
+	return result, nil
+}
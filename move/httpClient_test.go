@@ -0,0 +1,238 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test authenticated HTTP inputs
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_httpAuthentication(test *testing.T) {
+	tokenFile := filepath.Join(test.TempDir(), "token")
+	require.NoError(test, os.WriteFile(tokenFile, []byte("file-token\n"), 0o600))
+
+	testCases := []struct {
+		name    string
+		mover   move.BasicMove
+		check   func(request *http.Request) bool
+		wantErr bool
+	}{
+		{
+			name:  "headers",
+			mover: move.BasicMove{HTTPHeaders: []string{"X-Api-Key: key-1", "x-tenant:  acme "}},
+			check: func(request *http.Request) bool {
+				return request.Header.Get("X-Api-Key") == "key-1" && request.Header.Get("X-Tenant") == "acme"
+			},
+		},
+		{
+			name:  "bearer token",
+			mover: move.BasicMove{HTTPBearerToken: "env-token"},
+			check: func(request *http.Request) bool {
+				return request.Header.Get("Authorization") == "Bearer env-token"
+			},
+		},
+		{
+			name:  "bearer token file",
+			mover: move.BasicMove{HTTPBearerToken: "env-token", HTTPBearerTokenFile: tokenFile},
+			check: func(request *http.Request) bool {
+				return request.Header.Get("Authorization") == "Bearer file-token"
+			},
+		},
+		{
+			name:  "basic auth",
+			mover: move.BasicMove{HTTPBasicAuthUser: "user", HTTPBasicAuthPassword: "password"},
+			check: func(request *http.Request) bool {
+				user, password, ok := request.BasicAuth()
+
+				return ok && user == "user" && password == "password"
+			},
+		},
+		{
+			name:    "missing credentials",
+			check:   func(request *http.Request) bool { return request.Header.Get("Authorization") != "" },
+			wantErr: true,
+		},
+		{
+			name:    "bad header",
+			mover:   move.BasicMove{HTTPHeaders: []string{"X-Api-Key key-1"}},
+			check:   func(*http.Request) bool { return true },
+			wantErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				if !testCase.check(request) {
+					writer.WriteHeader(http.StatusUnauthorized)
+
+					return
+				}
+
+				_, _ = writer.Write([]byte(testGoodData))
+			}))
+			defer server.Close()
+
+			mover := testCase.mover
+			testHTTPInput(test, &mover, server.URL+"/test.jsonl", testCase.wantErr)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_httpTLS(test *testing.T) {
+	dir := test.TempDir()
+	clientCert, clientKey, clientPool := createClientCertificate(test, dir)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(testGoodData))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientPool,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+
+	defer server.Close()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(test, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}), 0o600))
+
+	testCases := []struct {
+		name    string
+		mover   move.BasicMove
+		wantErr bool
+	}{
+		{
+			name:  "CA bundle and client certificate",
+			mover: move.BasicMove{HTTPCACertFile: caFile, HTTPClientCertFile: clientCert, HTTPClientKeyFile: clientKey},
+		},
+		{
+			name:    "untrusted server",
+			mover:   move.BasicMove{HTTPClientCertFile: clientCert, HTTPClientKeyFile: clientKey},
+			wantErr: true,
+		},
+		{
+			name:    "no client certificate",
+			mover:   move.BasicMove{HTTPCACertFile: caFile},
+			wantErr: true,
+		},
+		{
+			name:    "client certificate without key",
+			mover:   move.BasicMove{HTTPCACertFile: caFile, HTTPClientCertFile: clientCert},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			mover := testCase.mover
+			mover.HTTPRetries = move.HTTPRetriesNone
+			testHTTPInput(test, &mover, server.URL+"/test.jsonl", testCase.wantErr)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_httpProxy(test *testing.T) {
+	var proxiedHost string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		proxiedHost = request.URL.Host
+		_, _ = writer.Write([]byte(testGoodData))
+	}))
+	defer proxy.Close()
+
+	mover := &move.BasicMove{HTTPProxy: proxy.URL}
+	testHTTPInput(test, mover, "http://data.example.com/test.jsonl", false)
+	require.Equal(test, "data.example.com", proxiedHost)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Move an HTTP input to a file with the given mover.
+func testHTTPInput(t *testing.T, mover *move.BasicMove, inputURL string, wantErr bool) {
+	t.Helper()
+
+	_, writer, cleanUp := mockStdout(t)
+	defer cleanUp()
+
+	outputFile := filepath.Join(t.TempDir(), "output.jsonl")
+	mover.InputURL = inputURL
+	mover.OutputURL = "file://" + outputFile
+
+	err := mover.Move(t.Context())
+	writer.Close()
+
+	if wantErr {
+		require.Error(t, err)
+
+		return
+	}
+
+	require.NoError(t, err)
+	require.Equal(t, 12, countLines(t, outputFile))
+}
+
+// Create a self-signed client certificate, returning its certificate and key
+// files and a pool trusting it.
+func createClientCertificate(t *testing.T, dir string) (string, string, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		NotAfter:     time.Now().Add(time.Hour),
+		NotBefore:    time.Now().Add(-time.Hour),
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "move-test-client"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+
+	return certFile, keyFile, pool
+}
@@ -0,0 +1,248 @@
+package move
+
+import (
+	"context"
+	"errors"
+	"hash"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// InputResult describes the reading of a single input.
+type InputResult struct {
+	// The number of bytes read, as stored, and their SHA-256 in hex.
+	Bytes int64 `json:"bytes"`
+	// The delivery of the records read, when the writer must acknowledge
+	// them.
+	delivery *delivery
+	digest   hash.Hash
+	// The records hooks dropped by returning no records in their place.
+	Dropped int `json:"dropped"`
+	// The lines read that were dropped as duplicates, per the dedupe options.
+	Duplicates int   `json:"duplicates"`
+	Err        error `json:"-"`
+	// The SHA-256 the bytes read must have, if any, and whether they had
+	// another.
+	expectedSHA256 string
+	mismatched     bool
+	// The lines read that the filter did not match.
+	Filtered int    `json:"filtered"`
+	Input    string `json:"input"`
+	Lines    int    `json:"lines"`
+	Moved    int    `json:"moved"`
+	// The lines read that were invalid and the records a hook rejected or
+	// made invalid.
+	Rejected int    `json:"rejected"`
+	SHA256   string `json:"sha256"`
+}
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+// InputResults returns the result of each input read by the last Move, in
+// the order the inputs were given.
+func (move *BasicMove) InputResults() []InputResult {
+	return slices.Clone(move.inputResults)
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// The input URLs to read, with glob patterns and directories in file URLs
+// replaced by the files they name.  The path of the input each file came from
+// is kept in expandedFrom.
+func (move *BasicMove) expandInputs() ([]string, error) {
+	var result []string
+
+	move.expandedFrom = map[string]string{}
+
+	inputURLs := move.InputURLs
+	if move.InputURL != "" {
+		inputURLs = append([]string{move.InputURL}, inputURLs...)
+	}
+
+	for _, inputURL := range inputURLs {
+		inputURL, err := expandEnvReferences(inputURL)
+		if err != nil {
+			return nil, err
+		}
+
+		parsedURL, err := url.Parse(inputURL)
+		if err != nil || parsedURL.Scheme != "file" {
+			// Reported when the input is read.
+			result = append(result, inputURL)
+
+			continue
+		}
+
+		files, err := move.expandPath(parsedURL.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(files) == 0 {
+			return nil, wraperror.Errorf(errForPackage, "no input files found for %s", inputURL)
+		}
+
+		input := filepath.Clean(parsedURL.Path)
+
+		for _, file := range files {
+			result = append(result, fileURL(file))
+
+			if file != input {
+				move.expandedFrom[file] = input
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// The files named by a path, which may be a glob pattern or a directory.
+// A path naming neither is returned as is so that reading reports the error.
+func (move *BasicMove) expandPath(path string) ([]string, error) {
+	if strings.ContainsAny(path, "*?[") {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "filepath.Glob %s", path)
+		}
+
+		return matches, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return []string{path}, nil //nolint:nilerr
+	}
+
+	var result []string
+
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if file != path && !move.InputRecursive {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		name := strings.TrimSuffix(file, ageSuffix)
+		if move.FileType != "" || strings.HasSuffix(name, "jsonl") || strings.HasSuffix(name, "gz") {
+			result = append(result, file)
+		}
+
+		return nil
+	})
+
+	return result, wraperror.Errorf(err, "filepath.WalkDir %s", path)
+}
+
+// ----------------------------------------------------------------------------
+
+// Read the inputs into the record channel, InputParallelism at a time.  A
+// failed input is logged and the rest are still read, unless
+// StopOnInputError is set.
+func (move *BasicMove) readInputs(ctx context.Context, inputs []string, recordchan chan queues.Record) error {
+	var waitGroup sync.WaitGroup
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]InputResult, len(inputs))
+	parallelism := move.InputParallelism
+
+	if move.Follow {
+		// Followed inputs are never finished, so all are read at once.
+		parallelism = len(inputs)
+	}
+
+	semaphore := make(chan struct{}, max(parallelism, 1))
+
+	// Create the shared HTTP client before inputs are read concurrently.
+	_, err := move.getHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	for index, input := range inputs {
+		results[index].Input = input
+
+		semaphore <- struct{}{}
+
+		if ctx.Err() != nil {
+			<-semaphore
+
+			results[index].Err = wraperror.Errorf(ctx.Err(), "not read")
+
+			continue
+		}
+
+		waitGroup.Add(1)
+
+		go func(result *InputResult) {
+			defer waitGroup.Done()
+			defer func() { <-semaphore }()
+
+			result.Err = move.readVerified(ctx, result, func(result *InputResult) error {
+				return move.readInput(ctx, result.Input, recordchan, result)
+			})
+			if result.Err != nil {
+				move.log(3015, result.Input, result.Err)
+
+				if move.StopOnInputError {
+					cancel()
+				}
+			}
+
+			move.log(2007, result.Input, result.Lines, result.Moved)
+		}(&results[index])
+	}
+
+	waitGroup.Wait()
+
+	move.inputResults = results
+
+	var errs []error
+
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, wraperror.Errorf(result.Err, "input %s", RedactURL(result.Input)))
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	return wraperror.Errorf(errors.Join(errs...), "%d of %d inputs failed", len(errs), len(inputs))
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The file URL of a path, escaped so that a path containing '#', '?' or '%'
+// reads back as the same path.
+func fileURL(path string) string {
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,311 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test moving from several inputs
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_inputs(test *testing.T) {
+	inputDir := createInputDir(test)
+
+	testCases := []struct {
+		name             string
+		inputURLs        []string
+		inputRecursive   bool
+		parallelism      int
+		stopOnInputError bool
+		expectedInputs   []string
+		expectedMoved    int
+		wantErr          bool
+	}{
+		{
+			name:           "several URLs",
+			inputURLs:      []string{"file://" + inputDir + "/a.jsonl", "file://" + inputDir + "/b.jsonl.gz"},
+			expectedInputs: []string{"a.jsonl", "b.jsonl.gz"},
+			expectedMoved:  5,
+		},
+		{
+			name:           "glob",
+			inputURLs:      []string{"file://" + inputDir + "/*.jsonl"},
+			expectedInputs: []string{"a.jsonl", "c.jsonl"},
+			expectedMoved:  3,
+		},
+		{
+			name:           "directory",
+			inputURLs:      []string{"file://" + inputDir},
+			expectedInputs: []string{"a.jsonl", "b.jsonl.gz", "c.jsonl"},
+			expectedMoved:  6,
+		},
+		{
+			name:           "recursive directory",
+			inputURLs:      []string{"file://" + inputDir},
+			inputRecursive: true,
+			parallelism:    2,
+			expectedInputs: []string{"a.jsonl", "b.jsonl.gz", "c.jsonl", "sub/d.jsonl"},
+			expectedMoved:  7,
+		},
+		{
+			name:           "bad input does not stop the rest",
+			inputURLs:      []string{"file://" + inputDir + "/missing.jsonl", "file://" + inputDir + "/a.jsonl"},
+			expectedInputs: []string{"missing.jsonl", "a.jsonl"},
+			expectedMoved:  2,
+			wantErr:        true,
+		},
+		{
+			name:             "stop on input error",
+			inputURLs:        []string{"file://" + inputDir + "/missing.jsonl", "file://" + inputDir + "/a.jsonl"},
+			stopOnInputError: true,
+			expectedInputs:   []string{"missing.jsonl", "a.jsonl"},
+			expectedMoved:    0,
+			wantErr:          true,
+		},
+		{
+			name:      "glob without matches",
+			inputURLs: []string{"file://" + inputDir + "/*.csv"},
+			wantErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			_, writer, cleanUp := mockStdout(test)
+			defer cleanUp()
+
+			outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+			mover := &move.BasicMove{
+				InputParallelism: testCase.parallelism,
+				InputRecursive:   testCase.inputRecursive,
+				InputURLs:        testCase.inputURLs,
+				OutputURL:        "file://" + outputFile,
+				StopOnInputError: testCase.stopOnInputError,
+			}
+
+			err := mover.Move(test.Context())
+			writer.Close()
+
+			if testCase.wantErr {
+				require.Error(test, err)
+			} else {
+				require.NoError(test, err)
+			}
+
+			var inputs []string
+
+			moved := 0
+
+			for _, result := range mover.InputResults() {
+				relative, err := filepath.Rel(inputDir, result.Input[len("file://"):])
+				require.NoError(test, err)
+
+				inputs = append(inputs, relative)
+				moved += result.Moved
+			}
+
+			require.Equal(test, testCase.expectedInputs, inputs)
+			require.Equal(test, testCase.expectedMoved, moved)
+
+			if testCase.expectedMoved > 0 {
+				require.Equal(test, testCase.expectedMoved, countLines(test, outputFile))
+			}
+		})
+	}
+}
+
+func TestBasicMove_Move_inputs_data_source(test *testing.T) {
+	inputDir := createInputDir(test)
+
+	testCases := []struct {
+		name          string
+		inputURL      string
+		setDataSource []string
+		expected      map[string]int
+	}{
+		{
+			name:          "directory",
+			inputURL:      "file://" + inputDir,
+			setDataSource: []string{"file://" + inputDir + "=DIRECTORY", "c.jsonl=FILE", "GENERAL"},
+			expected:      map[string]int{"DIRECTORY": 5, "FILE": 1},
+		},
+		{
+			name:          "directory with a trailing slash",
+			inputURL:      "file://" + inputDir + "/",
+			setDataSource: []string{inputDir + "=DIRECTORY"},
+			expected:      map[string]int{"DIRECTORY": 6},
+		},
+		{
+			name:          "glob",
+			inputURL:      "file://" + inputDir + "/*.jsonl",
+			setDataSource: []string{"*.jsonl=GLOB", "GENERAL"},
+			expected:      map[string]int{"GLOB": 3},
+		},
+		{
+			name:          "other input",
+			inputURL:      "file://" + inputDir,
+			setDataSource: []string{"other=OTHER", "GENERAL"},
+			expected:      map[string]int{"GENERAL": 6},
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			_, writer, cleanUp := mockStdout(test)
+			defer cleanUp()
+
+			outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+			mover := &move.BasicMove{
+				InputURL:      testCase.inputURL,
+				OutputURL:     "file://" + outputFile,
+				SetDataSource: testCase.setDataSource,
+			}
+
+			err := mover.Move(test.Context())
+			writer.Close()
+			require.NoError(test, err)
+
+			actual := map[string]int{}
+
+			for _, line := range readLines(test, outputFile) {
+				var record struct {
+					DataSource string `json:"DATA_SOURCE"`
+				}
+
+				require.NoError(test, json.Unmarshal([]byte(line), &record))
+
+				actual[record.DataSource]++
+			}
+
+			require.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestBasicMove_Move_inputs_special_characters(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputDir := test.TempDir()
+	names := []string{"a#1.jsonl", "b?2.jsonl", "c%3.jsonl"}
+
+	for index, name := range names {
+		content := fmt.Sprintf(`{"DATA_SOURCE": "TEST", "RECORD_ID": "%d"}`+"\n", index)
+		require.NoError(test, os.WriteFile(filepath.Join(inputDir, name), []byte(content), 0o600))
+	}
+
+	outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+	mover := &move.BasicMove{
+		InputURLs: []string{"file://" + inputDir},
+		OutputURL: "file://" + outputFile,
+	}
+
+	err := mover.Move(test.Context())
+	writer.Close()
+	require.NoError(test, err)
+
+	require.Equal(test, len(names), countLines(test, outputFile))
+
+	for _, result := range mover.InputResults() {
+		require.NoError(test, result.Err)
+		require.Equal(test, 1, result.Moved)
+	}
+}
+
+func TestBasicMove_Move_inputs_sequence_record_id(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputDir := test.TempDir()
+
+	for _, dir := range []string{"dir1", "dir2"} {
+		require.NoError(test, os.MkdirAll(filepath.Join(inputDir, dir), 0o750))
+		require.NoError(test, os.WriteFile(
+			filepath.Join(inputDir, dir, "records.jsonl"),
+			[]byte(`{"DATA_SOURCE": "TEST", "NAME_FULL": "Bob Smith"}`+"\n"),
+			0o600,
+		))
+	}
+
+	outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+	mover := &move.BasicMove{
+		GenerateRecordID: move.GenerateRecordIDSequence,
+		InputURLs:        []string{"file://" + inputDir + "/dir*/records.jsonl"},
+		OutputURL:        "file://" + outputFile,
+	}
+
+	err := mover.Move(test.Context())
+	writer.Close()
+	require.NoError(test, err)
+
+	recordIDs := map[string]bool{}
+
+	for _, line := range readLines(test, outputFile) {
+		var record struct {
+			RecordID string `json:"RECORD_ID"`
+		}
+
+		require.NoError(test, json.Unmarshal([]byte(line), &record))
+
+		recordIDs[record.RecordID] = true
+	}
+
+	require.Len(test, recordIDs, 2)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Create a directory of inputs:
+//
+//	a.jsonl       2 records
+//	b.jsonl.gz    3 records
+//	c.jsonl       1 record and 1 invalid line
+//	notes.txt     ignored
+//	sub/d.jsonl   1 record
+func createInputDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	writeRecords := func(name string, ids ...int) {
+		content := ""
+		for _, id := range ids {
+			content += fmt.Sprintf(`{"DATA_SOURCE": "TEST", "RECORD_ID": "%s-%d"}`+"\n", name, id)
+		}
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	writeRecords("a.jsonl", 1, 2)
+	writeRecords("sub/d.jsonl", 1)
+	writeRecords("notes.txt", 1)
+
+	gzipFile, cleanUp := createTempGZIPDataFile(t, `{"DATA_SOURCE": "TEST", "RECORD_ID": "b-1"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "b-2"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "b-3"}
+`)
+	t.Cleanup(cleanUp)
+
+	content, err := os.ReadFile(gzipFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.jsonl.gz"), content, 0o600))
+
+	c := `{"DATA_SOURCE": "TEST", "RECORD_ID": "c-1"}` + "\n" + `{"DATA_SOURCE": "TEST"}` + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.jsonl"), []byte(c), 0o600))
+
+	return dir
+}
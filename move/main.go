@@ -38,10 +38,29 @@ var IDMessages = map[int]string{
 	2001: Prefix + "Records sent to queue: %d",
 	2002: Prefix + "GoVersion: %s, Path: %s, Main.Path: %s, Main.Version: %s",
 	2003: Prefix + "CPUs: %d, Go routines: %d, CGO calls: %d, Num GC: %d, GC pause total: %v, LastGC: %v, TotalAlloc: %d, HeapAlloc: %d, NextGC: %d, GCSys: %d, HeapSys: %d, StackSys: %d, Sys - total OS bytes: %d, CPU fraction used by GC: %f",
+	2004: Prefix + "Duplicate records, exact: %d, conflicting: %d",
+	2005: Prefix + "Records not matching filter: %d",
+	2006: Prefix + "Records rejected by hook %s: %d",
+	2007: Prefix + "Input %s, lines read: %d, records moved: %d",
+	2008: Prefix + "Followed input %s was replaced, reading the new file",
+	2009: Prefix + "Followed input %s was truncated, reading from the start",
+	2010: Prefix + "Connected to %s",
+	2011: Prefix + "Wrote audit manifest %s",
 	// WARN 	3000-3999 	Unexpected situations, but processing was successful
 	3001: Prefix + "Error closing file %s: %+v",
 	3010: Prefix + "Error validating line %d %+v",
 	3011: Prefix + "Unable to read build info.",
+	3012: Prefix + "Warning validating line %d %+v",
+	3013: Prefix + "Duplicate record at line %d, message ID %s: %s",
+	3014: Prefix + "Hook %s rejected line %d: %+v",
+	3015: Prefix + "Error reading input %s: %+v",
+	3016: Prefix + "Error watching %s: %+v",
+	3017: Prefix + "Retrying %s from byte %d, attempt %d of %d, after: %+v",
+	3018: Prefix + "Reconnecting to %s in %v, attempt %d of %d, after: %+v",
+	3019: Prefix + "Sending %d records to %s again, attempt %d of %d, after: %+v",
+	3020: Prefix + "Input %s has SHA-256 %s, expected %s; its %d records were moved anyway",
+	3021: Prefix + "Removed output %s, as inputs failed their SHA-256 checks",
+	3022: Prefix + "Unable to remove output %s of inputs that failed their SHA-256 checks: %+v",
 	// ERROR 	4000-4999 	Unexpected situations, processing was not successful
 	// FATAL 	5000-5999 	The process needs to shutdown
 	5000: Prefix + "Fatal error, Check the input-url parameter: %s",
@@ -16,11 +16,11 @@ import (
 	"sync"
 	"time"
 
+	"filippo.io/age"
 	"github.com/senzing-garage/go-helpers/record"
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-logging/logging"
 	"github.com/senzing-garage/go-queueing/queues"
-	"github.com/senzing-garage/go-queueing/queues/rabbitmq"
 	"github.com/senzing-garage/go-queueing/queues/sqs"
 )
 
@@ -33,16 +33,99 @@ type Error struct {
 }
 
 type BasicMove struct {
+	AgeIdentityFile           string
+	ageIdentities             []age.Identity
+	AgeRecipients             []string
+	ageRecipients             []age.Recipient
+	AgeRecipientsFile         string
+	AMQPCACertFile            string
+	AMQPClientCertFile        string
+	AMQPClientKeyFile         string
+	AMQPConfirm               bool
+	AMQPDurable               bool
+	AMQPExchange              string
+	AMQPExchangeType          string
+	AMQPHeaders               bool
+	AMQPPassword              string
+	AMQPPasswordFile          string
+	AMQPQueue                 string
+	AMQPRoutingKey            string
+	AMQPServerName            string
+	AMQPSkipDeclare           bool
+	AMQPTLSMinVersion         string
+	AMQPTransient             bool
+	AMQPUsername              string
+	AuditManifest             string
+	Configuration             map[string]any
+	Dedupe                    string
+	dedupe                    *deduplicator
+	DedupeDivertFile          string
+	DedupeKey                 string
+	DedupeMaxEntries          int
+	DefaultDataSource         []string
+	expandedFrom              map[string]string
 	FileType                  string
+	Follow                    bool
+	FollowPollInterval        time.Duration
+	FollowStateDirectory      string
+	Filter                    string
+	fileSecrets               *fileSecrets
+	filter                    *FilterExpression
+	filtered                  int64
+	GenerateRecordID          string
+	hooks                     []*registeredHook
+	HTTPBasicAuthPassword     string
+	HTTPBasicAuthUser         string
+	HTTPBearerToken           string
+	HTTPBearerTokenFile       string
+	HTTPCACertFile            string
+	httpClient                *http.Client
+	HTTPClientCertFile        string
+	HTTPClientKeyFile         string
+	httpHeader                http.Header
+	HTTPHeaders               []string
+	HTTPProxy                 string
+	HTTPReadTimeout           time.Duration
+	HTTPRetries               int
+	HTTPRetryBackoff          time.Duration
+	HTTPTimeout               time.Duration
+	InputChecksumURL          string
+	InputParallelism          int
+	InputRecursive            bool
+	InputSHA256               []string
+	inputResults              []InputResult
 	InputURL                  string
+	InputURLs                 []string
 	JSONOutput                bool
+	MessageIDStrategy         string
 	logger                    logging.Logging
 	LogLevel                  string
 	MonitoringPeriodInSeconds int
+	Operation                 []string
+	OperationAttribute        string
+	OutputEnvelope            string
 	OutputURL                 string
+	OutputURLFile             string
 	RecordMax                 int
 	RecordMin                 int
 	RecordMonitor             int
+	RedactDrop                []string
+	RedactHash                []string
+	RedactKey                 string
+	RedactMask                []string
+	RunID                     string
+	SetDataSource             []string
+	SQSMessageGroupID         string
+	skipValidation            bool
+	StatsFormat               string
+	StopOnInputError          bool
+	TransformFile             string
+	transform                 *Transform
+	ValidationLevel           string
+	Version                   string
+	WatchDirectory            string
+	WatchManifest             string
+	WatchRequireMarker        bool
 }
 
 const (
@@ -74,6 +157,13 @@ func (move *BasicMove) Move(ctx context.Context) error {
 		err      error
 	)
 
+	startTime := time.Now()
+
+	err = move.validateOptions()
+	if err != nil {
+		return err
+	}
+
 	move.logBuildInfo()
 	move.logStats()
 
@@ -97,21 +187,25 @@ func (move *BasicMove) Move(ctx context.Context) error {
 	var waitGroup sync.WaitGroup
 
 	recordchan := make(chan queues.Record, numChannels)
+	readchan := recordchan
+
+	var output *outputDigest
+
+	if move.AuditManifest != "" {
+		readchan = make(chan queues.Record, numChannels)
+		output = move.digestOutput(readchan, recordchan)
+	}
+
+	// Followed inputs never end, so following stops when the writer fails.
+	readCtx, cancelRead := context.WithCancelCause(ctx)
+	defer cancelRead(nil)
 
 	waitGroup.Add(1)
 
 	go func() {
 		defer waitGroup.Done()
 
-		readErr = move.read(ctx, recordchan)
-		if readErr != nil {
-			select {
-			case <-recordchan:
-				// channel already closed
-			default:
-				close(recordchan)
-			}
-		}
+		readErr = move.read(readCtx, readchan)
 	}()
 
 	waitGroup.Add(1)
@@ -120,14 +214,40 @@ func (move *BasicMove) Move(ctx context.Context) error {
 		defer waitGroup.Done()
 
 		writeErr = move.write(ctx, recordchan)
+		if writeErr != nil {
+			discardRecords(recordchan)
+
+			if move.Follow {
+				cancelRead(writeErr)
+			}
+		}
 	}()
 
 	waitGroup.Wait()
 
+	if move.Filter != "" {
+		move.log(2005, move.Filtered())
+	}
+
+	move.logHookErrors()
+
+	err = move.closeDedupe()
+
 	if readErr != nil {
-		return readErr
+		err = readErr
 	} else if writeErr != nil {
-		return writeErr
+		err = writeErr
+	}
+
+	if writeErr == nil {
+		move.removeUnverifiedOutput()
+	}
+
+	if move.AuditManifest != "" {
+		auditErr := move.writeAuditManifest(startTime, output, err)
+		if err == nil {
+			err = auditErr
+		}
 	}
 
 	return err
@@ -138,34 +258,37 @@ func (move *BasicMove) Move(ctx context.Context) error {
 // Process records in the JSONL format; reading one record per line from
 // the given reader and placing the records into the record channel.
 func (move *BasicMove) ProcessJSONL(fileName string, reader io.Reader, recordchan chan queues.Record) {
-	scanner := bufio.NewScanner(reader)
-	scanner.Split(bufio.ScanLines)
+	move.ProcessJSONLContext(context.Background(), fileName, reader, recordchan)
+}
 
-	iteration := 0
-	for scanner.Scan() {
-		iteration++
-		if iteration < move.RecordMin {
-			continue
-		}
+// ----------------------------------------------------------------------------
 
-		str := strings.TrimSpace(scanner.Text())
-		// ignore blank lines
-		if len(str) > 0 {
-			valid, err := record.Validate(str)
-			if valid {
-				recordchan <- &SzRecord{str, iteration, fileName}
-			} else {
-				move.log(3010, iteration, err)
-			}
-		}
+// ProcessJSONLContext is ProcessJSONL with a context, which is passed to
+// record hooks.
+func (move *BasicMove) ProcessJSONLContext(
+	ctx context.Context,
+	fileName string,
+	reader io.Reader,
+	recordchan chan queues.Record,
+) {
+	err := move.startFilter()
+	if err != nil {
+		move.log(3015, fileName, err)
+		close(recordchan)
 
-		if (move.RecordMonitor > 0) && (iteration%move.RecordMonitor == 0) {
-			move.log(2001, iteration)
-		}
+		return
+	}
 
-		if move.RecordMax > 0 && iteration >= (move.RecordMax) {
-			break
-		}
+	move.startDedupe()
+
+	err = move.processJSONL(ctx, fileName, reader, recordchan, &InputResult{})
+	if err != nil {
+		move.log(3015, fileName, err)
+	}
+
+	err = move.closeDedupe()
+	if err != nil {
+		move.log(3015, fileName, err)
 	}
 
 	close(recordchan)
@@ -175,88 +298,119 @@ func (move *BasicMove) ProcessJSONL(fileName string, reader io.Reader, recordcha
 
 // Opens and reads a JSONL file.
 func (move *BasicMove) ReadJSONLFile(jsonFile string, recordchan chan queues.Record) error {
-	cleanJSONFile := filepath.Clean(jsonFile)
+	return move.ReadJSONLFileContext(context.Background(), jsonFile, recordchan)
+}
 
-	file, err := os.Open(cleanJSONFile)
+// ----------------------------------------------------------------------------
+
+// ReadJSONLFileContext is ReadJSONLFile with a context.
+func (move *BasicMove) ReadJSONLFileContext(ctx context.Context, jsonFile string, recordchan chan queues.Record) error {
+	err := move.startFilter()
 	if err != nil {
-		return wraperror.Errorf(err, "os.Open")
+		return err
 	}
 
-	defer file.Close()
+	move.startDedupe()
 
-	move.ProcessJSONL(jsonFile, file, recordchan)
+	err = move.readJSONLFile(ctx, jsonFile, recordchan, &InputResult{})
+	closeErr := move.closeDedupe()
 
-	return nil
+	if err != nil {
+		return err
+	}
+
+	close(recordchan)
+
+	return closeErr
 }
 
 // ----------------------------------------------------------------------------
 
 // Opens and reads a JSONL file that has been GZIPped.
 func (move *BasicMove) ReadGZIPFile(gzipFileName string, recordchan chan queues.Record) error {
-	cleanGzipFileName := filepath.Clean(gzipFileName)
+	return move.ReadGZIPFileContext(context.Background(), gzipFileName, recordchan)
+}
 
-	gzipfile, err := os.Open(cleanGzipFileName)
+// ----------------------------------------------------------------------------
+
+// ReadGZIPFileContext is ReadGZIPFile with a context.
+func (move *BasicMove) ReadGZIPFileContext(ctx context.Context, gzipFileName string, recordchan chan queues.Record) error {
+	err := move.startFilter()
 	if err != nil {
-		return wraperror.Errorf(err, "os.Open")
+		return err
 	}
 
-	defer gzipfile.Close()
+	move.startDedupe()
+
+	err = move.readGZIPFile(ctx, gzipFileName, recordchan, &InputResult{})
+	closeErr := move.closeDedupe()
 
-	reader, err := gzip.NewReader(gzipfile)
 	if err != nil {
-		return wraperror.Errorf(err, "gzip.NewReader")
+		return err
 	}
-	defer reader.Close()
 
-	move.ProcessJSONL(gzipFileName, reader, recordchan)
+	close(recordchan)
 
-	return nil
+	return closeErr
 }
 
 // ----------------------------------------------------------------------------
 
 // Opens and reads a JSONL http resource.
 func (move *BasicMove) ReadJSONLResource(jsonURL string, recordchan chan queues.Record) error {
-	//nolint:noctx
-	response, err := http.Get(jsonURL) //nolint:gosec
+	return move.ReadJSONLResourceContext(context.Background(), jsonURL, recordchan)
+}
+
+// ----------------------------------------------------------------------------
+
+// ReadJSONLResourceContext is ReadJSONLResource with a context.
+func (move *BasicMove) ReadJSONLResourceContext(ctx context.Context, jsonURL string, recordchan chan queues.Record) error {
+	err := move.startFilter()
 	if err != nil {
-		return wraperror.Errorf(err, "http.Get")
+		return err
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return wraperror.Errorf(errForPackage, "unable to retrieve: %s, return code: %d", jsonURL, response.StatusCode)
-	}
+	move.startDedupe()
+
+	err = move.readJSONLResource(ctx, jsonURL, recordchan, &InputResult{})
+	closeErr := move.closeDedupe()
 
-	defer response.Body.Close()
+	if err != nil {
+		return err
+	}
 
-	move.ProcessJSONL(jsonURL, response.Body, recordchan)
+	close(recordchan)
 
-	return nil
+	return closeErr
 }
 
+// ----------------------------------------------------------------------------
+
 func (move *BasicMove) ReadGZIPResource(gzipURL string, recordchan chan queues.Record) error {
-	//nolint:noctx
-	response, err := http.Get(gzipURL) //nolint:gosec
+	return move.ReadGZIPResourceContext(context.Background(), gzipURL, recordchan)
+}
+
+// ----------------------------------------------------------------------------
+
+// ReadGZIPResourceContext is ReadGZIPResource with a context.
+func (move *BasicMove) ReadGZIPResourceContext(ctx context.Context, gzipURL string, recordchan chan queues.Record) error {
+	err := move.startFilter()
 	if err != nil {
-		return wraperror.Errorf(err, "fatal error retrieving inputURL: %s", gzipURL)
+		return err
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return wraperror.Errorf(errForPackage, "unable to retrieve: %s, return code: %d", gzipURL, response.StatusCode)
-	}
+	move.startDedupe()
 
-	defer response.Body.Close()
+	err = move.readGZIPResource(ctx, gzipURL, recordchan, &InputResult{})
+	closeErr := move.closeDedupe()
 
-	reader, err := gzip.NewReader(response.Body)
 	if err != nil {
-		return wraperror.Errorf(err, "gzip.NewReader")
+		return err
 	}
 
-	defer reader.Close()
-
-	move.ProcessJSONL(gzipURL, reader, recordchan)
+	close(recordchan)
 
-	return nil
+	return closeErr
 }
 
 /*
@@ -292,12 +446,21 @@ func (move *BasicMove) WriteStdout(recordchan chan queues.Record) error {
 		return wraperror.Errorf(err, "fatal error opening stdout")
 	}
 
+	var acknowledger flushAcknowledger
+
 	writer := bufio.NewWriter(os.Stdout)
 	for record := range recordchan {
-		_, err := writer.WriteString(record.GetMessage() + "\n")
+		_, err := writer.WriteString(move.messageFor(record) + "\n")
 		if err != nil {
 			return wraperror.Errorf(err, "error writing to stdout")
 		}
+
+		acknowledger.add(record)
+
+		err = acknowledger.flushIdle(recordchan, writer.Flush)
+		if err != nil {
+			return wraperror.Errorf(err, "error flushing stdout")
+		}
 	}
 
 	err = writer.Flush()
@@ -312,13 +475,310 @@ func (move *BasicMove) WriteStdout(recordchan chan queues.Record) error {
 // -- Private methods
 // ----------------------------------------------------------------------------
 
+// ----------------------------------------------------------------------------
+
+// Process records in the JSONL format without closing the record channel,
+// counting the lines read and records moved in result.  Returns the error, if
+// any, that stopped the reader before its end.
+func (move *BasicMove) processJSONL(
+	ctx context.Context,
+	fileName string,
+	reader io.Reader,
+	recordchan chan queues.Record,
+	result *InputResult,
+) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Split(bufio.ScanLines)
+
+	iteration := 0
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			break
+		}
+
+		iteration++
+		if !move.processIteration(ctx, fileName, iteration, scanner.Text(), recordchan, result) {
+			break
+		}
+	}
+
+	err := scanner.Err()
+	if err == nil && result.expectedSHA256 != "" && ctx.Err() == nil {
+		// Read what the record range left, to verify the whole input.
+		_, err = io.Copy(io.Discard, reader)
+	}
+
+	return wraperror.Errorf(err, "reading %s", fileName)
+}
+
+// ----------------------------------------------------------------------------
+
+// Process line iteration of an input, applying the record range and
+// monitoring options.  Reports whether reading should continue.
+func (move *BasicMove) processIteration(
+	ctx context.Context,
+	fileName string,
+	iteration int,
+	text string,
+	recordchan chan queues.Record,
+	result *InputResult,
+) bool {
+	if iteration < move.RecordMin {
+		return true
+	}
+
+	str := strings.TrimSpace(text)
+	// ignore blank lines
+	if len(str) > 0 {
+		result.Lines++
+		result.Moved += move.processLine(ctx, fileName, iteration, str, recordchan, result)
+	}
+
+	if (move.RecordMonitor > 0) && (iteration%move.RecordMonitor == 0) {
+		move.log(2001, iteration)
+	}
+
+	return move.RecordMax <= 0 || iteration < move.RecordMax
+}
+
+// ----------------------------------------------------------------------------
+
+// Process a single, non-blank JSON line; placing it into the record channel
+// if it is valid.  Returns the number of records placed in the channel.
+func (move *BasicMove) processLine(
+	ctx context.Context,
+	fileName string,
+	iteration int,
+	line string,
+	recordchan chan queues.Record,
+	result *InputResult,
+) int {
+	line, carriedOperation := unwrapEnvelope(line)
+
+	line, err := move.rewrite(fileName, iteration, line)
+	if err != nil {
+		move.log(3010, iteration, err)
+		result.Rejected++
+
+		return 0
+	}
+
+	valid, warnings, err := move.validate(line)
+	for _, warning := range warnings {
+		move.log(3012, iteration, warning)
+	}
+
+	if !valid {
+		move.log(3010, iteration, err)
+		result.Rejected++
+
+		return 0
+	}
+
+	matched, err := move.checkFilter(line)
+	if err != nil {
+		move.log(3010, iteration, err)
+		result.Rejected++
+
+		return 0
+	}
+
+	if !matched {
+		result.Filtered++
+
+		return 0
+	}
+
+	operation, err := move.operationFor(fileName, line, carriedOperation)
+	if err != nil {
+		move.log(3010, iteration, err)
+		result.Rejected++
+
+		return 0
+	}
+
+	record := &SzRecord{
+		Body:              line,
+		ID:                iteration,
+		IngestedAt:        time.Now().UTC(),
+		MessageIDStrategy: move.MessageIDStrategy,
+		Operation:         operation,
+		Source:            fileName,
+	}
+
+	keep, err := move.checkDuplicate(record)
+	if err != nil {
+		move.log(3010, iteration, err)
+		result.Rejected++
+
+		return 0
+	}
+
+	if !keep {
+		result.Duplicates++
+
+		return 0
+	}
+
+	if len(move.hooks) == 0 {
+		result.send(record, recordchan)
+
+		return 1
+	}
+
+	moved := 0
+
+	outputs, rejected, dropped := move.runHooks(ctx, record)
+	result.Dropped += dropped
+	result.Rejected += rejected
+
+	for _, output := range outputs {
+		valid, _, err := move.validate(output.Body)
+		if !valid {
+			move.log(3010, output.ID, err)
+			result.Rejected++
+
+			continue
+		}
+
+		result.send(output, recordchan)
+		moved++
+	}
+
+	return moved
+}
+
+// ----------------------------------------------------------------------------
+
+// Validate a single JSON line, unless validation has been turned off.
+// Warnings are only produced by strict validation.
+func (move *BasicMove) validate(line string) (bool, []string, error) {
+	if move.skipValidation {
+		return true, nil, nil
+	}
+
+	if strings.EqualFold(move.ValidationLevel, ValidationLevelStrict) {
+		result := ValidateStrict(line)
+
+		return result.Valid(), result.Warnings, result.Err()
+	}
+
+	valid, err := record.Validate(line)
+
+	return valid, nil, wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+
+// Check the options that are not checked as they are used.
+func (move *BasicMove) validateOptions() error {
+	switch strings.ToLower(move.ValidationLevel) {
+	case "", ValidationLevelBasic, ValidationLevelStrict:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown validation level: %s", move.ValidationLevel)
+	}
+
+	switch strings.ToLower(move.GenerateRecordID) {
+	case "", GenerateRecordIDHash, GenerateRecordIDSequence, GenerateRecordIDUUID:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown RECORD_ID generator: %s", move.GenerateRecordID)
+	}
+
+	switch strings.ToLower(move.MessageIDStrategy) {
+	case "", MessageIDContentHash, MessageIDRecordKey, MessageIDSourceLine:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown message ID strategy: %s", move.MessageIDStrategy)
+	}
+
+	switch strings.ToLower(move.Dedupe) {
+	case "", DedupeDrop, DedupeOff, DedupeReport:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown dedupe mode: %s", move.Dedupe)
+	}
+
+	err := move.startFilter()
+	if err != nil {
+		return err
+	}
+
+	move.startDedupe()
+
+	err = move.validateRedaction()
+	if err != nil {
+		return err
+	}
+
+	err = move.validateOperations()
+	if err != nil {
+		return err
+	}
+
+	err = move.validateOutputEnvelope()
+	if err != nil {
+		return err
+	}
+
+	err = move.validateChecksums()
+	if err != nil {
+		return err
+	}
+
+	err = move.validateEncryption()
+	if err != nil {
+		return err
+	}
+
+	move.fileSecrets = &fileSecrets{}
+
+	_, err = move.getHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	err = move.validateAMQPOptions()
+	if err != nil {
+		return err
+	}
+
+	if move.TransformFile != "" {
+		transform, err := LoadTransform(move.TransformFile)
+		if err != nil {
+			return err
+		}
+
+		move.transform = transform
+	}
+
+	switch strings.ToLower(move.DedupeKey) {
+	case "", MessageIDContentHash, MessageIDRecordKey:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown dedupe key: %s", move.DedupeKey)
+	}
+
+	switch strings.ToLower(move.StatsFormat) {
+	case "", StatsFormatJSON, StatsFormatTable:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown stats format: %s", move.StatsFormat)
+	}
+
+	return nil
+}
+
 // ----------------------------------------------------------------------------
 // -- Write implementation: writes records in the record channel to the output
 // ----------------------------------------------------------------------------
 
 // This function implements writing to RabbitMQ.
 func (move *BasicMove) write(ctx context.Context, recordchan chan queues.Record) error {
-	outputURL := move.OutputURL
+	outputURL, err := move.ResolveOutputURL()
+	if err != nil {
+		return err
+	}
+
+	err = move.checkEncryptedOutput(outputURL)
+	if err != nil {
+		return err
+	}
 
 	outputURLLen := len(outputURL)
 	if outputURLLen == 0 {
@@ -329,36 +789,63 @@ func (move *BasicMove) write(ctx context.Context, recordchan chan queues.Record)
 	// This assumes the URL includes a schema and path so, minimally:
 	//  "s://p" where the schema is 's' and 'p' is the complete path
 	if len(outputURL) < len("s://p") {
-		return wraperror.Errorf(errForPackage, "invalid outputURL: %s", outputURL)
+		return wraperror.Errorf(errForPackage, "invalid outputURL: %s", RedactURL(outputURL))
 	}
 
 	parsedURL, err := url.Parse(outputURL)
 	if err != nil {
-		return wraperror.Errorf(err, "invalid outputURL: %s", outputURL)
+		return wraperror.Errorf(RedactError(err), "invalid outputURL: %s", RedactURL(outputURL))
 	}
 
 	switch parsedURL.Scheme {
-	case "amqp":
-		rabbitmq.StartManagedProducer(ctx, outputURL, runtime.GOMAXPROCS(0), recordchan, move.LogLevel, move.JSONOutput)
+	case "amqp", "amqps":
+		err = move.writeAMQP(ctx, outputURL, recordchan)
+		if err != nil {
+			return err
+		}
 	case "file":
+		name := strings.TrimSuffix(parsedURL.Path, ageSuffix)
+
 		switch {
-		case strings.HasSuffix(parsedURL.Path, "jsonl"), strings.ToUpper(move.FileType) == JSONL:
+		case strings.HasSuffix(name, "jsonl"), strings.ToUpper(move.FileType) == JSONL:
 			return move.writeJSONLFile(parsedURL.Path, recordchan)
-		case strings.HasSuffix(parsedURL.Path, "gz"), strings.ToUpper(move.FileType) == "GZ":
+		case strings.HasSuffix(name, "gz"), strings.ToUpper(move.FileType) == "GZ":
 			return move.writeGZIPFile(parsedURL.Path, recordchan)
 		default:
 			// IMPROVE: process JSON file?
 			return wraperror.Errorf(errForPackage, "only able to process JSON-Lines files at this time")
 		}
-	case "sqs":
-		// allows for using a dummy URL with just a queue-name
+	case "sqs", "https":
+		// "sqs" allows for using a dummy URL with just a queue-name
 		// eg  sqs://lookup?queue-name=myqueue
-		sqs.StartManagedProducer(ctx, outputURL, runtime.GOMAXPROCS(0), recordchan, move.LogLevel, move.JSONOutput)
-	case "https":
-		// uses actual AWS SQS URL  IMPROVE: detect sqs/amazonaws url?
-		sqs.StartManagedProducer(ctx, outputURL, runtime.GOMAXPROCS(0), recordchan, move.LogLevel, move.JSONOutput)
+		// "https" uses actual AWS SQS URL  IMPROVE: detect sqs/amazonaws url?
+		if isSQSFIFOQueue(outputURL) || move.isTrackingOperations() || move.isAcknowledging() ||
+			strings.EqualFold(move.OutputEnvelope, OutputEnvelopeJSON) {
+			// FIFO queues keep the order records are sent in, which the
+			// managed producer's parallel workers do not, and the managed
+			// producer does not send the operation or envelope of records
+			// nor acknowledge records as they are sent.  Records that carry
+			// an operation from an envelope are split off below.
+			err = move.writeSQS(ctx, outputURL, recordchan)
+			if err != nil {
+				return err
+			}
+
+			break
+		}
+
+		managedchan, waitForOperations := move.splitOperations(recordchan, func(operationchan chan queues.Record) error {
+			return move.writeSQS(ctx, outputURL, operationchan)
+		})
+
+		sqs.StartManagedProducer(ctx, outputURL, runtime.GOMAXPROCS(0), managedchan, move.LogLevel, move.JSONOutput)
+
+		err = waitForOperations()
+		if err != nil {
+			return err
+		}
 	default:
-		return wraperror.Errorf(errForPackage, "unknown scheme, unable to write to: %s", outputURL)
+		return wraperror.Errorf(errForPackage, "unknown scheme, unable to write to: %s", RedactURL(outputURL))
 	}
 
 	move.log(2000)
@@ -391,12 +878,26 @@ func (move *BasicMove) writeJSONLFile(fileName string, recordchan chan queues.Re
 		return wraperror.Errorf(err, "fatal error opening %s", fileName)
 	}
 
-	writer := bufio.NewWriter(file)
+	encrypted, err := move.encryptWriter(fileName, file)
+	if err != nil {
+		return err
+	}
+
+	var acknowledger flushAcknowledger
+
+	writer := bufio.NewWriter(encrypted)
 	for record := range recordchan {
-		_, err := writer.WriteString(record.GetMessage() + "\n")
+		_, err := writer.WriteString(move.messageFor(record) + "\n")
 		if err != nil {
 			return wraperror.Errorf(err, "error writing to stdout")
 		}
+
+		acknowledger.add(record)
+
+		err = acknowledger.flushIdle(recordchan, writer.Flush)
+		if err != nil {
+			return wraperror.Errorf(err, "error flushing %s", fileName)
+		}
 	}
 
 	err = writer.Flush()
@@ -404,6 +905,11 @@ func (move *BasicMove) writeJSONLFile(fileName string, recordchan chan queues.Re
 		return wraperror.Errorf(err, "error flushing %s", fileName)
 	}
 
+	err = encrypted.Close()
+	if err != nil {
+		return wraperror.Errorf(err, "error encrypting %s", fileName)
+	}
+
 	return nil
 }
 
@@ -432,15 +938,38 @@ func (move *BasicMove) writeGZIPFile(fileName string, recordchan chan queues.Rec
 		return wraperror.Errorf(err, "fatal error opening %s", fileName)
 	}
 
-	gzfile := gzip.NewWriter(file)
+	encrypted, err := move.encryptWriter(fileName, file)
+	if err != nil {
+		return err
+	}
+
+	gzfile := gzip.NewWriter(encrypted)
 	defer gzfile.Close()
 
+	var acknowledger flushAcknowledger
+
 	writer := bufio.NewWriter(gzfile)
+	flush := func() error {
+		err := writer.Flush()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return gzfile.Flush() //nolint:wrapcheck
+	}
+
 	for record := range recordchan {
-		_, err := writer.WriteString(record.GetMessage() + "\n")
+		_, err := writer.WriteString(move.messageFor(record) + "\n")
 		if err != nil {
 			return wraperror.Errorf(err, "error writing to stdout")
 		}
+
+		acknowledger.add(record)
+
+		err = acknowledger.flushIdle(recordchan, flush)
+		if err != nil {
+			return wraperror.Errorf(err, "error flushing %s", fileName)
+		}
 	}
 
 	err = writer.Flush()
@@ -448,6 +977,17 @@ func (move *BasicMove) writeGZIPFile(fileName string, recordchan chan queues.Rec
 		return wraperror.Errorf(err, "error flushing %s", fileName)
 	}
 
+	// The compressed data ends before the encrypted file does.
+	err = gzfile.Close()
+	if err != nil {
+		return wraperror.Errorf(err, "error compressing %s", fileName)
+	}
+
+	err = encrypted.Close()
+	if err != nil {
+		return wraperror.Errorf(err, "error encrypting %s", fileName)
+	}
+
 	return nil
 }
 
@@ -455,52 +995,83 @@ func (move *BasicMove) writeGZIPFile(fileName string, recordchan chan queues.Rec
 // -- Read implementation: reads records from the input to the record channel
 // ----------------------------------------------------------------------------
 
-// this function attempts to determine the source of records.
-// it then parses the source and puts the records into the record channel.
+// this function attempts to determine the sources of records.
+// it then parses the sources and puts the records into the record channel,
+// which is closed once every source has been read.
 func (move *BasicMove) read(ctx context.Context, recordchan chan queues.Record) error {
-	_ = ctx
+	defer close(recordchan)
 
-	inputURL := move.InputURL
-	inputURLLen := len(inputURL)
+	inputs, err := move.expandInputs()
+	if err != nil {
+		return err
+	}
 
-	if inputURLLen == 0 {
+	if len(inputs) == 0 {
 		// assume stdin
-		return move.readStdin(recordchan)
+		result := &InputResult{Input: "stdin"}
+		result.Err = move.readVerified(ctx, result, func(result *InputResult) error {
+			return move.readStdin(ctx, recordchan, result)
+		})
+		move.inputResults = []InputResult{*result}
+
+		return result.Err
 	}
 
+	return move.readInputs(ctx, inputs, recordchan)
+}
+
+// ----------------------------------------------------------------------------
+
+// Read a single input URL.
+func (move *BasicMove) readInput(
+	ctx context.Context,
+	inputURL string,
+	recordchan chan queues.Record,
+	result *InputResult,
+) error {
 	// This assumes the URL includes a schema and path so, minimally:
 	//  "s://p" where the schema is 's' and 'p' is the complete path
 	if len(inputURL) < len("s://p") {
 		move.log(5000, inputURL)
 
-		return wraperror.Errorf(errForPackage, "check the inputURL parameter: %s", inputURL)
+		return wraperror.Errorf(errForPackage, "check the inputURL parameter: %s", RedactURL(inputURL))
 	}
 
 	parsedURL, err := url.Parse(inputURL)
 	if err != nil {
-		return wraperror.Errorf(err, "url.Parse")
+		return wraperror.Errorf(RedactError(err), "url.Parse")
 	}
 
+	if move.Follow {
+		if parsedURL.Scheme != "file" || !isFollowable(parsedURL.Path, move.FileType) {
+			return wraperror.Errorf(errForPackage, "only file:// JSONL inputs can be followed: %s", RedactURL(inputURL))
+		}
+
+		return move.followJSONLFile(ctx, parsedURL.Path, recordchan, result)
+	}
+
+	// Encrypted inputs are named for their format before encryption.
+	name := strings.TrimSuffix(parsedURL.Path, ageSuffix)
+
 	switch parsedURL.Scheme {
 	case "file":
 		switch {
-		case strings.HasSuffix(parsedURL.Path, "jsonl"), strings.ToUpper(move.FileType) == JSONL:
-			return move.ReadJSONLFile(parsedURL.Path, recordchan)
-		case strings.HasSuffix(parsedURL.Path, "gz"), strings.ToUpper(move.FileType) == "GZ":
-			return move.ReadGZIPFile(parsedURL.Path, recordchan)
+		case strings.HasSuffix(name, "jsonl"), strings.ToUpper(move.FileType) == JSONL:
+			return move.readJSONLFile(ctx, parsedURL.Path, recordchan, result)
+		case strings.HasSuffix(name, "gz"), strings.ToUpper(move.FileType) == "GZ":
+			return move.readGZIPFile(ctx, parsedURL.Path, recordchan, result)
 		default:
 			// IMPROVE: process JSON file?
-			close(recordchan)
 			move.log(5011)
 
 			return wraperror.Errorf(errForPackage, "unable to process file://%s", parsedURL.Path)
 		}
 	case "http", "https":
 		switch {
-		case strings.HasSuffix(parsedURL.Path, "jsonl"), strings.ToUpper(move.FileType) == JSONL:
-			return move.ReadJSONLResource(inputURL, recordchan)
-		case strings.HasSuffix(parsedURL.Path, "gz"), strings.ToUpper(move.FileType) == "GZ":
-			return move.ReadGZIPResource(inputURL, recordchan)
+		case strings.HasSuffix(name, "jsonl"), strings.ToUpper(move.FileType) == JSONL:
+			return move.readJSONLResource(ctx, inputURL, recordchan, result)
+		case strings.HasSuffix(name, "gz"), strings.ToUpper(move.FileType) == "GZ":
+			return move.readGZIPResource(ctx, inputURL, recordchan, result)
 		default:
 			move.log(5012)
 
@@ -513,7 +1084,120 @@ func (move *BasicMove) read(ctx context.Context, recordchan chan queues.Record)
 
 // ----------------------------------------------------------------------------
 
-func (move *BasicMove) readStdin(recordchan chan queues.Record) error {
+// Opens and reads a JSONL file.
+func (move *BasicMove) readJSONLFile(
+	ctx context.Context,
+	jsonFile string,
+	recordchan chan queues.Record,
+	result *InputResult,
+) error {
+	cleanJSONFile := filepath.Clean(jsonFile)
+
+	file, err := os.Open(cleanJSONFile)
+	if err != nil {
+		return wraperror.Errorf(err, "os.Open")
+	}
+
+	defer file.Close()
+
+	reader, err := move.decryptReader(jsonFile, result.digestReader(file))
+	if err != nil {
+		return err
+	}
+
+	return move.processJSONL(ctx, jsonFile, reader, recordchan, result)
+}
+
+// ----------------------------------------------------------------------------
+
+// Opens and reads a JSONL file that has been GZIPped.
+func (move *BasicMove) readGZIPFile(
+	ctx context.Context,
+	gzipFileName string,
+	recordchan chan queues.Record,
+	result *InputResult,
+) error {
+	cleanGzipFileName := filepath.Clean(gzipFileName)
+
+	gzipfile, err := os.Open(cleanGzipFileName)
+	if err != nil {
+		return wraperror.Errorf(err, "os.Open")
+	}
+
+	defer gzipfile.Close()
+
+	decrypted, err := move.decryptReader(gzipFileName, result.digestReader(gzipfile))
+	if err != nil {
+		return err
+	}
+
+	reader, err := gzip.NewReader(decrypted)
+	if err != nil {
+		return wraperror.Errorf(err, "gzip.NewReader")
+	}
+	defer reader.Close()
+
+	return move.processJSONL(ctx, gzipFileName, reader, recordchan, result)
+}
+
+// ----------------------------------------------------------------------------
+
+// Opens and reads a JSONL http resource.
+func (move *BasicMove) readJSONLResource(
+	ctx context.Context,
+	jsonURL string,
+	recordchan chan queues.Record,
+	result *InputResult,
+) error {
+	body, err := move.openResource(ctx, jsonURL)
+	if err != nil {
+		return err
+	}
+
+	defer body.Close()
+
+	reader, err := move.decryptReader(resourcePath(jsonURL), result.digestReader(body))
+	if err != nil {
+		return err
+	}
+
+	return move.processJSONL(ctx, jsonURL, reader, recordchan, result)
+}
+
+// ----------------------------------------------------------------------------
+
+// Opens and reads a JSONL http resource that has been GZIPped.
+func (move *BasicMove) readGZIPResource(
+	ctx context.Context,
+	gzipURL string,
+	recordchan chan queues.Record,
+	result *InputResult,
+) error {
+	body, err := move.openResource(ctx, gzipURL)
+	if err != nil {
+		return err
+	}
+
+	defer body.Close()
+
+	decrypted, err := move.decryptReader(resourcePath(gzipURL), result.digestReader(body))
+	if err != nil {
+		return err
+	}
+
+	reader, err := gzip.NewReader(decrypted)
+	if err != nil {
+		return wraperror.Errorf(err, "gzip.NewReader")
+	}
+
+	defer reader.Close()
+
+	return move.processJSONL(ctx, gzipURL, reader, recordchan, result)
+}
+
+// ----------------------------------------------------------------------------
+
+func (move *BasicMove) readStdin(ctx context.Context, recordchan chan queues.Record, result *InputResult) error {
 	info, err := os.Stdin.Stat()
 	if err != nil {
 		return wraperror.Errorf(err, "fatal error reading stdin")
@@ -521,10 +1205,8 @@ func (move *BasicMove) readStdin(recordchan chan queues.Record) error {
 	// printFileInfo(info)
 
 	if info.Mode()&os.ModeNamedPipe == os.ModeNamedPipe {
-		reader := bufio.NewReader(os.Stdin)
-		move.ProcessJSONL("stdin", reader, recordchan)
-
-		return nil
+		reader := bufio.NewReader(result.digestReader(os.Stdin))
+		return move.processJSONL(ctx, "stdin", reader, recordchan, result)
 	}
 
 	return wraperror.Errorf(err, wraperror.NoMessage)
@@ -555,9 +1237,9 @@ func (move *BasicMove) getLogger() logging.Logging {
 // Log message.
 func (move *BasicMove) log(messageNumber int, details ...interface{}) {
 	if move.JSONOutput {
-		move.getLogger().Log(messageNumber, details...)
+		move.getLogger().Log(messageNumber, move.redactDetails(details)...)
 	} else {
-		outputln(fmt.Sprintf(IDMessages[messageNumber], details...))
+		outputln(RedactText(fmt.Sprintf(IDMessages[messageNumber], details...), move.secrets()...))
 	}
 }
 
@@ -617,3 +1299,14 @@ func (move *BasicMove) logStats() {
 func outputln(message ...any) {
 	fmt.Println(message...) //nolint
 }
+
+// ----------------------------------------------------------------------------
+
+// Keep reading the record channel, discarding the records, so that reading
+// finishes after the output has failed rather than block on a full channel.
+func discardRecords(recordchan chan queues.Record) {
+	go func() {
+		for range recordchan { //nolint:revive
+		}
+	}()
+}
@@ -366,6 +366,29 @@ func TestBasicMove_Move_unknown_resource_type(test *testing.T) {
 	writer.Close()
 }
 
+// Test the move method, with an output it cannot write to.  Reading must
+// not block on the records the output no longer takes.
+func TestBasicMove_Move_unknown_output_scheme(test *testing.T) {
+	ctx := test.Context()
+
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	// create a temporary jsonl file of good test data
+	filename, cleanUpTempFile := createTempDataFile(test, strings.Repeat(testGoodData, 10), "jsonl")
+	defer cleanUpTempFile()
+
+	mover := &move.BasicMove{
+		InputURL:  "file://" + filename,
+		OutputURL: "bogus://host/queue",
+	}
+
+	err := mover.Move(ctx)
+	require.Error(test, err)
+
+	writer.Close()
+}
+
 // Test the move method, with a single jsonl file.
 func TestBasicMove_Move_wait_for_logStats(test *testing.T) {
 	ctx := test.Context()
@@ -941,6 +964,10 @@ func mockStdout(t *testing.T) (*os.File, *os.File, func()) {
 	reader, writer, err := os.Pipe()
 	require.NoError(t, err)
 
+	// Callers often ignore the reader; keep it open until the test ends so
+	// that writes do not fail when it is garbage collected.
+	t.Cleanup(func() { _ = reader.Close() })
+
 	os.Stdout = writer
 
 	return reader,
@@ -964,6 +991,10 @@ func mockStderr(t *testing.T) (*os.File, *os.File, func()) {
 	reader, writer, err := os.Pipe()
 	require.NoError(t, err)
 
+	// Callers often ignore the reader; keep it open until the test ends so
+	// that writes do not fail when it is garbage collected.
+	t.Cleanup(func() { _ = reader.Close() })
+
 	os.Stderr = writer
 
 	return reader,
@@ -0,0 +1,157 @@
+package move
+
+import (
+	"cmp"
+	"strings"
+	"sync"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Operations of records, telling loaders what to do with them.
+const (
+	OperationAdd     = "add"
+	OperationDelete  = "delete"
+	OperationReplace = "replace"
+)
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Report whether the operation of records is tracked.
+func (move *BasicMove) isTrackingOperations() bool {
+	return move.OperationAttribute != "" || len(move.Operation) > 0
+}
+
+// ----------------------------------------------------------------------------
+
+// The operation of a record read from source: the value of its
+// OperationAttribute, else the operation carried by the envelope the record
+// was read from, else the operation given for the input in Operation, else
+// add.  Without operations tracked, only a carried operation is kept.
+func (move *BasicMove) operationFor(source string, line string, carried string) (string, error) {
+	if !move.isTrackingOperations() {
+		if carried == "" {
+			return "", nil
+		}
+
+		return parseOperation(carried)
+	}
+
+	if move.OperationAttribute != "" {
+		attributes, err := parseRecordBody(line)
+		if err == nil && attributes[move.OperationAttribute] != nil {
+			value, isString := attributes[move.OperationAttribute].(string)
+			if !isString {
+				return "", wraperror.Errorf(errForPackage, "%s is not a string", move.OperationAttribute)
+			}
+
+			if strings.TrimSpace(value) != "" {
+				return parseOperation(value)
+			}
+		}
+	}
+
+	operation := cmp.Or(carried, move.mappingFor(source, move.Operation))
+	if operation == "" {
+		return OperationAdd, nil
+	}
+
+	return parseOperation(operation)
+}
+
+// ----------------------------------------------------------------------------
+
+// Split off the records that carry an operation, which the managed producers
+// drop, to be written by write, which is started for the first of them.  The
+// rest of the records are passed on in the returned channel.  The returned
+// function waits for write, once the returned channel has been read, and
+// returns its error.
+func (move *BasicMove) splitOperations(
+	recordchan chan queues.Record,
+	write func(chan queues.Record) error,
+) (chan queues.Record, func() error) {
+	var (
+		waitGroup sync.WaitGroup
+		writeErr  error
+	)
+
+	result := make(chan queues.Record, numChannels)
+
+	go func() {
+		var operationchan chan queues.Record
+
+		for record := range recordchan {
+			szRecord, isSzRecord := record.(*SzRecord)
+			if !isSzRecord || szRecord.Operation == "" {
+				result <- record
+
+				continue
+			}
+
+			if operationchan == nil {
+				operationchan = make(chan queues.Record, numChannels)
+
+				waitGroup.Add(1)
+
+				go func() {
+					defer waitGroup.Done()
+
+					writeErr = write(operationchan)
+					if writeErr != nil {
+						discardRecords(operationchan)
+					}
+				}()
+			}
+
+			operationchan <- record
+		}
+
+		if operationchan != nil {
+			close(operationchan)
+		}
+
+		close(result)
+	}()
+
+	return result, func() error {
+		waitGroup.Wait()
+
+		return writeErr
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Check the operations given for inputs.
+func (move *BasicMove) validateOperations() error {
+	for _, mapping := range move.Operation {
+		_, err := parseOperation(mapping[strings.LastIndex(mapping, "=")+1:])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func parseOperation(value string) (string, error) {
+	operation := strings.ToLower(strings.TrimSpace(value))
+
+	switch operation {
+	case OperationAdd, OperationDelete, OperationReplace:
+		return operation, nil
+	default:
+		return "", wraperror.Errorf(errForPackage, "unknown operation: %s", value)
+	}
+}
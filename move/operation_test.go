@@ -0,0 +1,93 @@
+package move_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test the operation of records
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_processJSONL_operation(test *testing.T) {
+	input := `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "ACTION": "DELETE"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "2"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "3", "ACTION": "remove"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "4", "ACTION": 1}
+`
+
+	testCases := []struct {
+		name               string
+		operation          []string
+		operationAttribute string
+		expected           []string
+	}{
+		{
+			name:     "not tracked",
+			expected: []string{"", "", "", ""},
+		},
+		{
+			name:      "operation for every input",
+			operation: []string{"replace"},
+			expected:  []string{"replace", "replace", "replace", "replace"},
+		},
+		{
+			name:      "operation per input",
+			operation: []string{"add", "vendor.jsonl=delete"},
+			expected:  []string{"delete", "delete", "delete", "delete"},
+		},
+		{
+			name:      "operation for another input",
+			operation: []string{"other.jsonl=delete"},
+			expected:  []string{"add", "add", "add", "add"},
+		},
+		{
+			name:               "operation attribute",
+			operationAttribute: "ACTION",
+			operation:          []string{"replace"},
+			expected:           []string{"delete", "replace"},
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			_, writer, cleanUp := mockStdout(test)
+			defer cleanUp()
+
+			recordchan := make(chan queues.Record, 5)
+
+			mover := &move.BasicMove{
+				Operation:          testCase.operation,
+				OperationAttribute: testCase.operationAttribute,
+			}
+			mover.ProcessJSONLContext(test.Context(), "/data/vendor.jsonl", strings.NewReader(input), recordchan)
+
+			writer.Close()
+
+			actual := []string{}
+
+			for record := range recordchan {
+				szRecord, isSzRecord := record.(*move.SzRecord)
+				require.True(test, isSzRecord)
+
+				actual = append(actual, szRecord.Operation)
+			}
+
+			require.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_unknown_operation(test *testing.T) {
+	mover := &move.BasicMove{
+		Operation: []string{"input.jsonl=upsert"},
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package move_test
+
+// The race detector is on, so tests of the go-queueing managed producers,
+// which race on the state of their clients, are skipped.
+const raceDetector = true
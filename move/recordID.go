@@ -0,0 +1,106 @@
+package move
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// RECORD_ID generation
+// ----------------------------------------------------------------------------
+
+const (
+	GenerateRecordIDHash     = "hash"
+	GenerateRecordIDSequence = "sequence"
+	GenerateRecordIDUUID     = "uuid"
+)
+
+// Fill in the RECORD_ID of a record read from line iteration of source, if
+// it has none.  Reports whether the attributes were changed.
+func (move *BasicMove) applyRecordID(source string, iteration int, attributes map[string]any) (bool, error) {
+	if move.GenerateRecordID == "" || hasValue(attributes["RECORD_ID"]) {
+		return false, nil
+	}
+
+	var (
+		err      error
+		recordID string
+	)
+
+	switch strings.ToLower(move.GenerateRecordID) {
+	case GenerateRecordIDHash:
+		recordID, err = hashRecordBody(attributes)
+	case GenerateRecordIDSequence:
+		recordID = fmt.Sprintf("%s-%d", sequenceSource(source), iteration)
+	case GenerateRecordIDUUID:
+		recordID, err = newUUID()
+	default:
+		err = wraperror.Errorf(errForPackage, "unknown RECORD_ID generator: %s", move.GenerateRecordID)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	attributes["RECORD_ID"] = recordID
+
+	return true, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Hex encoded SHA-256 of the canonical form of the attributes; sorted keys
+// and numbers as written.  A RECORD_ID without a value is not included.
+func hashRecordBody(attributes map[string]any) (string, error) {
+	canonical := make(map[string]any, len(attributes))
+	for name, value := range attributes {
+		if name == "RECORD_ID" {
+			continue
+		}
+
+		canonical[name] = value
+	}
+
+	body, err := formatRecordBody(canonical)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(body))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// The source as it prefixes a sequence RECORD_ID.  The whole source is used,
+// not just its file name, so that same-named files in different directories
+// do not produce the same RECORD_IDs.
+func sequenceSource(source string) string {
+	if strings.Contains(source, "://") {
+		return source
+	}
+
+	return filepath.Clean(source)
+}
+
+// A random, version 4 UUID.
+func newUUID() (string, error) {
+	var uuid [16]byte
+
+	_, err := rand.Read(uuid[:])
+	if err != nil {
+		return "", wraperror.Errorf(err, "rand.Read")
+	}
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 //nolint:mnd
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 //nolint:mnd
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]), nil
+}
@@ -0,0 +1,104 @@
+package move_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test RECORD_ID generation while processing
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_processJSONL_generate_record_id(test *testing.T) {
+	input := `{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}
+{"DATA_SOURCE": "TEST", "NAME_FULL": "Bob Smith"}
+{"NAME_FULL": "Bob Smith", "DATA_SOURCE": "TEST", "RECORD_ID": ""}
+`
+
+	testCases := []struct {
+		name             string
+		generateRecordID string
+		expected         []string
+	}{
+		{
+			name:     "no generator",
+			expected: []string{`{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}`},
+		},
+		{
+			name:             "hash",
+			generateRecordID: move.GenerateRecordIDHash,
+			expected: []string{
+				`{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}`,
+				`{"DATA_SOURCE":"TEST","NAME_FULL":"Bob Smith","RECORD_ID":"b6d13df50650b318b89bf9949a95177420a3a2e5733688cfea8d7503375408db"}`,
+				`{"DATA_SOURCE":"TEST","NAME_FULL":"Bob Smith","RECORD_ID":"b6d13df50650b318b89bf9949a95177420a3a2e5733688cfea8d7503375408db"}`,
+			},
+		},
+		{
+			name:             "sequence",
+			generateRecordID: move.GenerateRecordIDSequence,
+			expected: []string{
+				`{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}`,
+				`{"DATA_SOURCE":"TEST","NAME_FULL":"Bob Smith","RECORD_ID":"/data/vendor.jsonl-2"}`,
+				`{"DATA_SOURCE":"TEST","NAME_FULL":"Bob Smith","RECORD_ID":"/data/vendor.jsonl-3"}`,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			require.Equal(test, testCase.expected, processWithRecordID(test, testCase.generateRecordID, input))
+		})
+	}
+}
+
+func TestBasicMove_processJSONL_generate_record_id_uuid(test *testing.T) {
+	input := `{"DATA_SOURCE": "TEST", "NAME_FULL": "Bob Smith"}
+{"DATA_SOURCE": "TEST", "NAME_FULL": "Bob Smith"}
+`
+
+	actual := processWithRecordID(test, move.GenerateRecordIDUUID, input)
+	require.Len(test, actual, 2)
+	require.Regexp(test, `"RECORD_ID":"[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}"`, actual[0])
+	require.NotEqual(test, actual[0], actual[1])
+}
+
+func TestBasicMove_Move_unknown_record_id_generator(test *testing.T) {
+	mover := &move.BasicMove{
+		GenerateRecordID: "bad",
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Process the input with the given RECORD_ID generator and return the bodies
+// of the records that were moved.
+func processWithRecordID(test *testing.T, generateRecordID string, input string) []string {
+	test.Helper()
+
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	recordchan := make(chan queues.Record, 5)
+
+	mover := &move.BasicMove{
+		GenerateRecordID: generateRecordID,
+	}
+	mover.ProcessJSONL("/data/vendor.jsonl", strings.NewReader(input), recordchan)
+
+	writer.Close()
+
+	result := []string{}
+	for record := range recordchan {
+		result = append(result, record.GetMessage())
+	}
+
+	return result
+}
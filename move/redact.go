@@ -0,0 +1,186 @@
+package move
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+const (
+	redactDrop = iota + 1
+	redactHash
+	redactMask
+)
+
+// The number of trailing letters and digits left visible by masking.
+const maskVisible = 4
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Report whether any attribute is to be redacted.
+func (move *BasicMove) isRedacting() bool {
+	return len(move.RedactDrop) > 0 || len(move.RedactHash) > 0 || len(move.RedactMask) > 0
+}
+
+// ----------------------------------------------------------------------------
+
+// Drop, hash or mask the configured attributes, at the top level of the
+// record and in feature lists.  Reports whether the attributes were changed.
+func (move *BasicMove) applyRedaction(attributes map[string]any) (bool, error) {
+	if !move.isRedacting() {
+		return false, nil
+	}
+
+	changed, err := move.redactAttributes("", attributes)
+	if err != nil {
+		return changed, err
+	}
+
+	for name, value := range attributes {
+		list, isList := value.([]any)
+		if !isList {
+			continue
+		}
+
+		for index, item := range list {
+			feature, isObject := item.(map[string]any)
+			if !isObject {
+				continue
+			}
+
+			featureChanged, err := move.redactAttributes(name+"["+strconv.Itoa(index)+"].", feature)
+			if err != nil {
+				return changed, err
+			}
+
+			changed = changed || featureChanged
+		}
+	}
+
+	return changed, nil
+}
+
+// ----------------------------------------------------------------------------
+
+func (move *BasicMove) redactAttributes(prefix string, attributes map[string]any) (bool, error) {
+	changed := false
+
+	for name, value := range attributes {
+		action := move.redaction(name)
+		if action == 0 {
+			continue
+		}
+
+		changed = true
+
+		if action == redactDrop {
+			delete(attributes, name)
+
+			continue
+		}
+
+		text, err := scalarText(prefix+name, value)
+		if err != nil {
+			return changed, err
+		}
+
+		if text == "" {
+			continue
+		}
+
+		if action == redactHash {
+			attributes[name] = hmacText(move.RedactKey, text)
+		} else {
+			attributes[name] = maskText(text)
+		}
+	}
+
+	return changed, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// The redaction for an attribute.  Dropping wins over hashing, which wins
+// over masking.
+func (move *BasicMove) redaction(name string) int {
+	switch {
+	case matchesAttribute(name, move.RedactDrop):
+		return redactDrop
+	case matchesAttribute(name, move.RedactHash):
+		return redactHash
+	case matchesAttribute(name, move.RedactMask):
+		return redactMask
+	default:
+		return 0
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func (move *BasicMove) validateRedaction() error {
+	if len(move.RedactHash) > 0 && move.RedactKey == "" {
+		return wraperror.Errorf(errForPackage, "hashing attributes requires a redact key")
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// HMAC-SHA256 of text, hex encoded.  The same key and text always give the
+// same value, so hashed records still resolve together.
+func hmacText(key string, text string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(text))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Replace the letters and digits of text with '*', except for the last few.
+// Punctuation is kept so the shape of the value is still recognizable.
+func maskText(text string) string {
+	runes := []rune(text)
+	visible := 0
+
+	for index := len(runes) - 1; index >= 0; index-- {
+		if !unicode.IsLetter(runes[index]) && !unicode.IsDigit(runes[index]) {
+			continue
+		}
+
+		if visible < maskVisible && len(runes) > maskVisible*2 {
+			visible++
+
+			continue
+		}
+
+		runes[index] = '*'
+	}
+
+	return string(runes)
+}
+
+// Report whether an attribute is one of names, either exactly or preceded by
+// a label, e.g. HOME_PHONE_NUMBER matches PHONE_NUMBER.
+func matchesAttribute(name string, names []string) bool {
+	for _, candidate := range names {
+		if strings.EqualFold(name, candidate) ||
+			strings.HasSuffix(strings.ToUpper(name), "_"+strings.ToUpper(candidate)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package move_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasicMove_processJSONL_redact(test *testing.T) {
+	data := `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "SSN_NUMBER": "123-45-6789", "DATE_OF_BIRTH": "1980-01-02", ` +
+		`"PHONES": [{"PHONE_NUMBER": "(555) 123-4567"}, {"HOME_PHONE_NUMBER": 5551234567}], "NAME_FULL": "Bob Smith"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "2", "DATE_OF_BIRTH": "1980-01-02", "PHONE_NUMBER": "1234"}
+`
+	recordchan := make(chan queues.Record, 2)
+
+	mover := &move.BasicMove{
+		RedactDrop: []string{"SSN_NUMBER"},
+		RedactHash: []string{"DATE_OF_BIRTH"},
+		RedactKey:  "secret",
+		RedactMask: []string{"PHONE_NUMBER"},
+	}
+	mover.ProcessJSONL("test.jsonl", strings.NewReader(data), recordchan)
+
+	first := <-recordchan
+	second := <-recordchan
+
+	// HMAC-SHA256 of "1980-01-02" with the key "secret".
+	hash := "ed97133f8d207f559b0c5ce001de39f90e16cd8c1a2b940d2f8b2b80e9096980"
+
+	require.JSONEq(
+		test,
+		`{"DATA_SOURCE":"TEST","DATE_OF_BIRTH":"`+hash+`","NAME_FULL":"Bob Smith","RECORD_ID":"1",`+
+			`"PHONES":[{"PHONE_NUMBER":"(***) ***-4567"},{"HOME_PHONE_NUMBER":"******4567"}]}`,
+		first.GetMessage(),
+	)
+
+	// Hashing is deterministic, so the same value hashes the same in every record.
+	require.Contains(test, second.GetMessage(), hash)
+	require.Contains(test, second.GetMessage(), `"PHONE_NUMBER":"****"`)
+}
+
+func TestBasicMove_Move_redact_hash_without_key(test *testing.T) {
+	mover := &move.BasicMove{
+		RedactHash: []string{"DATE_OF_BIRTH"},
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+}
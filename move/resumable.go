@@ -0,0 +1,298 @@
+package move
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A resumableReader reads an HTTP resource, retrying with backoff when the
+// connection fails and resuming at the first byte not yet read with a Range
+// request, so no record is read twice.
+type resumableReader struct {
+	body       io.ReadCloser
+	cancel     context.CancelFunc
+	client     *http.Client
+	ctx        context.Context //nolint:containedctx
+	move       *BasicMove
+	name       string
+	offset     int64
+	retryErr   error
+	resourceID string
+	url        string
+}
+
+// HTTPRetriesNone, as BasicMove.HTTPRetries, fails an http(s) input at its
+// first failed request.  Zero retries the default number of times.
+const HTTPRetriesNone = -1
+
+const (
+	defaultHTTPRetries      = 5
+	defaultHTTPRetryBackoff = time.Second
+	defaultHTTPTimeout      = 30 * time.Second
+	maxHTTPRetryBackoff     = 30 * time.Second
+)
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Open an HTTP resource for reading.
+func (move *BasicMove) openResource(ctx context.Context, resourceURL string) (io.ReadCloser, error) {
+	client, err := move.getHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &resumableReader{
+		client: client,
+		ctx:    ctx,
+		move:   move,
+		name:   RedactURL(resourceURL),
+		url:    resourceURL,
+	}
+
+	err = reader.reconnect()
+	if err != nil {
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// ----------------------------------------------------------------------------
+
+func (reader *resumableReader) Read(buffer []byte) (int, error) {
+	for {
+		if reader.body == nil {
+			err := reader.reconnect()
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		count, err := reader.readBody(buffer)
+		reader.offset += int64(count)
+
+		switch {
+		case err == nil, errors.Is(err, io.EOF):
+			return count, err //nolint:wrapcheck
+		case reader.ctx.Err() != nil:
+			return count, wraperror.Errorf(reader.ctx.Err(), "reading %s", reader.name)
+		}
+
+		// Return what was read; the next Read reconnects.
+		reader.closeBody()
+		reader.retryErr = err
+
+		if count > 0 {
+			return count, nil
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func (reader *resumableReader) Close() error {
+	reader.closeBody()
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Read from the body, abandoning the request if no data arrives within the
+// read timeout.
+func (reader *resumableReader) readBody(buffer []byte) (int, error) {
+	timeout := reader.move.HTTPReadTimeout
+	if timeout <= 0 {
+		return reader.body.Read(buffer) //nolint:wrapcheck
+	}
+
+	timer := time.AfterFunc(timeout, reader.cancel)
+	count, err := reader.body.Read(buffer)
+
+	if !timer.Stop() && err != nil {
+		err = fmt.Errorf("no data for %v: %w", timeout, err)
+	}
+
+	return count, err
+}
+
+// ----------------------------------------------------------------------------
+
+// Request the resource from the current offset, retrying with exponential
+// backoff.  Attempts are counted from the last failure so a long download
+// can survive many separate interruptions.
+func (reader *resumableReader) reconnect() error {
+	maxRetries := reader.move.HTTPRetries
+	if maxRetries == 0 {
+		maxRetries = defaultHTTPRetries
+	}
+
+	backoff := reader.move.HTTPRetryBackoff
+	if backoff <= 0 {
+		backoff = defaultHTTPRetryBackoff
+	}
+
+	for retries := 0; ; {
+		if reader.retryErr != nil {
+			if retries >= max(maxRetries, 0) {
+				return wraperror.Errorf(reader.retryErr, "giving up on %s after %d retries", reader.name, retries)
+			}
+
+			retries++
+			reader.move.log(3017, reader.name, reader.offset, retries, maxRetries, reader.retryErr)
+
+			select {
+			case <-reader.ctx.Done():
+				return wraperror.Errorf(reader.ctx.Err(), "reading %s", reader.name)
+			case <-time.After(backoff):
+			}
+
+			backoff = min(backoff*2, maxHTTPRetryBackoff) //nolint:mnd
+		}
+
+		retryable, err := reader.request()
+		if err == nil {
+			reader.retryErr = nil
+
+			return nil
+		}
+
+		if !retryable || reader.ctx.Err() != nil {
+			return err
+		}
+
+		reader.retryErr = err
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Make a single request for the resource from the current offset.  Reports
+// whether a failed request is worth retrying.
+func (reader *resumableReader) request() (bool, error) {
+	ctx, cancel := context.WithCancel(reader.ctx)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, reader.url, nil)
+	if err != nil {
+		cancel()
+
+		return false, wraperror.Errorf(err, "http.NewRequestWithContext")
+	}
+
+	if reader.offset > 0 {
+		request.Header.Set("Range", "bytes="+strconv.FormatInt(reader.offset, 10)+"-")
+
+		if reader.resourceID != "" {
+			request.Header.Set("If-Range", reader.resourceID)
+		}
+	}
+
+	err = reader.move.authorizeHTTPRequest(request)
+	if err != nil {
+		cancel()
+
+		return false, err
+	}
+
+	response, err := reader.client.Do(request)
+	if err != nil {
+		cancel()
+
+		// An untrusted certificate will not become trusted by retrying.
+		var verifyErr *tls.CertificateVerificationError
+
+		return !errors.As(err, &verifyErr), wraperror.Errorf(RedactError(err), "http.Do")
+	}
+
+	retryable, err := reader.accept(response)
+	if err != nil {
+		response.Body.Close()
+		cancel()
+
+		return retryable, err
+	}
+
+	reader.body = response.Body
+	reader.cancel = cancel
+
+	return false, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Check a response and position its body at the current offset.  Reports
+// whether an unacceptable response is worth retrying.
+func (reader *resumableReader) accept(response *http.Response) (bool, error) {
+	switch {
+	case response.StatusCode == http.StatusOK:
+		if reader.offset == 0 {
+			reader.resourceID = resourceID(response)
+
+			return false, nil
+		}
+
+		if resourceID(response) != reader.resourceID {
+			return false, wraperror.Errorf(errForPackage, "%s changed while resuming at byte %d", reader.name, reader.offset)
+		}
+
+		// The server ignored the Range; skip what was already read.
+		_, err := io.CopyN(io.Discard, response.Body, reader.offset)
+
+		return true, wraperror.Errorf(err, "skipping to byte %d", reader.offset)
+	case response.StatusCode == http.StatusPartialContent && reader.offset > 0:
+		if !strings.HasPrefix(response.Header.Get("Content-Range"), "bytes "+strconv.FormatInt(reader.offset, 10)+"-") {
+			return false, wraperror.Errorf(errForPackage, "unexpected Content-Range %q resuming %s at byte %d",
+				response.Header.Get("Content-Range"), reader.name, reader.offset)
+		}
+
+		return false, nil
+	case response.StatusCode == http.StatusTooManyRequests, response.StatusCode >= http.StatusInternalServerError:
+		return true, wraperror.Errorf(errForPackage, "unable to retrieve: %s, return code: %d", reader.name, response.StatusCode)
+	default:
+		return false, wraperror.Errorf(errForPackage, "unable to retrieve: %s, return code: %d", reader.name, response.StatusCode)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func (reader *resumableReader) closeBody() {
+	if reader.body != nil {
+		reader.body.Close()
+		reader.body = nil
+	}
+
+	if reader.cancel != nil {
+		reader.cancel()
+		reader.cancel = nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The validator used to check that a resumed resource has not changed: a
+// strong ETag or, failing that, the Last-Modified date.
+func resourceID(response *http.Response) string {
+	etag := response.Header.Get("ETag")
+	if etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+
+	return response.Header.Get("Last-Modified")
+}
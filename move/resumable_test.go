@@ -0,0 +1,224 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test resuming interrupted HTTP inputs
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_resumable(test *testing.T) {
+	testCases := []struct {
+		name          string
+		path          string
+		content       []byte
+		server        flakyServer
+		retries       int
+		readTimeout   time.Duration
+		expectedLines int
+		wantErr       bool
+	}{
+		{
+			name:          "JSONL dropped midway",
+			path:          "/test.jsonl",
+			content:       []byte(testGoodData),
+			server:        flakyServer{dropAfter: 700, supportsRange: true},
+			expectedLines: 12,
+		},
+		{
+			name:          "GZIP dropped midway",
+			path:          "/test.jsonl.gz",
+			content:       gzipContent(test, testGoodData),
+			server:        flakyServer{dropAfter: 200, supportsRange: true},
+			expectedLines: 12,
+		},
+		{
+			name:          "server without Range support",
+			path:          "/test.jsonl",
+			content:       []byte(testGoodData),
+			server:        flakyServer{dropAfter: 1500, dropRequests: 2},
+			expectedLines: 12,
+		},
+		{
+			name:          "stalled response",
+			path:          "/test.jsonl",
+			content:       []byte(testGoodData),
+			server:        flakyServer{dropAfter: 1000, dropRequests: 1, stall: true, supportsRange: true},
+			readTimeout:   100 * time.Millisecond,
+			expectedLines: 12,
+		},
+		{
+			name:    "retries exhausted",
+			path:    "/test.jsonl",
+			content: []byte(testGoodData),
+			server:  flakyServer{status: http.StatusServiceUnavailable},
+			retries: 2,
+			wantErr: true,
+		},
+		{
+			name:    "no retries",
+			path:    "/test.jsonl",
+			content: []byte(testGoodData),
+			server:  flakyServer{status: http.StatusServiceUnavailable},
+			retries: move.HTTPRetriesNone,
+			wantErr: true,
+		},
+		{
+			name:    "not found is not retried",
+			path:    "/test.jsonl",
+			content: []byte(testGoodData),
+			server:  flakyServer{status: http.StatusNotFound},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			_, writer, cleanUp := mockStdout(test)
+			defer cleanUp()
+
+			server := testCase.server
+			server.content = testCase.content
+			httpServer := httptest.NewServer(&server)
+
+			defer httpServer.Close()
+
+			outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+			mover := &move.BasicMove{
+				HTTPReadTimeout:  testCase.readTimeout,
+				HTTPRetries:      testCase.retries,
+				HTTPRetryBackoff: time.Millisecond,
+				InputURL:         httpServer.URL + testCase.path,
+				OutputURL:        "file://" + outputFile,
+			}
+
+			err := mover.Move(test.Context())
+			writer.Close()
+
+			if testCase.wantErr {
+				require.Error(test, err)
+
+				if testCase.server.status == http.StatusNotFound {
+					require.Equal(test, int64(1), atomic.LoadInt64(&server.requests))
+				} else {
+					require.Equal(test, int64(max(testCase.retries, 0)+1), atomic.LoadInt64(&server.requests))
+				}
+
+				return
+			}
+
+			require.NoError(test, err)
+			require.Greater(test, atomic.LoadInt64(&server.requests), int64(1))
+			require.Equal(test, testCase.expectedLines, mover.InputResults()[0].Lines)
+			require.Equal(test, testCase.expectedLines, countLines(test, outputFile))
+			require.Len(test, recordIDs(test, outputFile), testCase.expectedLines)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// A server that serves content but drops the connection after sending
+// dropAfter bytes of a response.
+type flakyServer struct {
+	content       []byte
+	dropAfter     int
+	dropRequests  int64
+	requests      int64
+	stall         bool
+	status        int
+	supportsRange bool
+}
+
+func (server *flakyServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	count := atomic.AddInt64(&server.requests, 1)
+
+	if server.status != 0 {
+		writer.WriteHeader(server.status)
+
+		return
+	}
+
+	start := 0
+	if rangeHeader := request.Header.Get("Range"); server.supportsRange && rangeHeader != "" {
+		start, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+	}
+
+	body := server.content[start:]
+	writer.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	writer.Header().Set("ETag", `"test"`)
+
+	if start > 0 {
+		writer.Header().Set("Content-Range",
+			"bytes "+strconv.Itoa(start)+"-"+strconv.Itoa(len(server.content)-1)+"/"+strconv.Itoa(len(server.content)))
+		writer.WriteHeader(http.StatusPartialContent)
+	}
+
+	if (server.dropRequests != 0 && count > server.dropRequests) || len(body) <= server.dropAfter {
+		_, _ = writer.Write(body)
+
+		return
+	}
+
+	_, _ = writer.Write(body[:server.dropAfter])
+	writer.(http.Flusher).Flush() //nolint:forcetypeassert
+
+	if server.stall {
+		<-request.Context().Done()
+	}
+
+	panic(http.ErrAbortHandler)
+}
+
+func gzipContent(t *testing.T, content string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buffer)
+	_, err := gzipWriter.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	return buffer.Bytes()
+}
+
+// The distinct record IDs in a JSONL file.
+func recordIDs(t *testing.T, fileName string) map[string]bool {
+	t.Helper()
+
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+
+	result := map[string]bool{}
+
+	for line := range strings.Lines(string(content)) {
+		var record struct {
+			RecordID string `json:"RECORD_ID"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+
+		result[record.RecordID] = true
+	}
+
+	return result
+}
@@ -0,0 +1,101 @@
+package move
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Rewrite implementation: alters records before they are validated
+// ----------------------------------------------------------------------------
+
+// Report whether any option that rewrites records before validation is set.
+func (move *BasicMove) isRewriting() bool {
+	return len(move.SetDataSource) > 0 ||
+		len(move.DefaultDataSource) > 0 ||
+		move.GenerateRecordID != "" ||
+		move.TransformFile != "" ||
+		move.isRedacting()
+}
+
+// ----------------------------------------------------------------------------
+
+// Apply the rewriting options to the JSON line read from line iteration of
+// source.  The line is returned unchanged when nothing needed to be rewritten
+// or when it is not a JSON object, in which case validation reports the
+// problem.
+func (move *BasicMove) rewrite(source string, iteration int, line string) (string, error) {
+	if !move.isRewriting() {
+		return line, nil
+	}
+
+	attributes, err := parseRecordBody(line)
+	if err != nil {
+		return line, nil //nolint:nilerr
+	}
+
+	transformed, err := move.applyTransform(attributes)
+	if err != nil {
+		return line, err
+	}
+
+	changed := move.applyDataSource(source, attributes)
+
+	generated, err := move.applyRecordID(source, iteration, attributes)
+	if err != nil {
+		return line, err
+	}
+
+	redacted, err := move.applyRedaction(attributes)
+	if err != nil {
+		return line, err
+	}
+
+	if !transformed && !changed && !generated && !redacted {
+		return line, nil
+	}
+
+	return formatRecordBody(attributes)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Format attributes as a single JSON line.  Keys are sorted and HTML
+// characters are not escaped.
+func formatRecordBody(attributes map[string]any) (string, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	err := encoder.Encode(attributes)
+	if err != nil {
+		return "", wraperror.Errorf(err, "json.Encode")
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// Parse a JSON line into its attributes, keeping numbers as written.
+func parseRecordBody(line string) (map[string]any, error) {
+	var attributes map[string]any
+
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&attributes)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "json.Decode")
+	}
+
+	if attributes == nil {
+		return nil, wraperror.Errorf(errForPackage, "not a JSON object")
+	}
+
+	return attributes, nil
+}
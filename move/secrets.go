@@ -0,0 +1,222 @@
+package move
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Masked replaces secrets in redacted text.
+const Masked = "********"
+
+// The secrets read from files, such as the AMQP password and the bearer
+// token, which is read again for every request and so may be rotated while
+// inputs are read.
+type fileSecrets struct {
+	mutex  sync.Mutex
+	values []string
+}
+
+// Query parameters whose values are credentials, compared without regard to
+// case.
+var secretQueryParameters = []string{
+	"access_token",
+	"api-key",
+	"api_key",
+	"apikey",
+	"auth",
+	"client_secret",
+	"password",
+	"passwd",
+	"pwd",
+	"secret",
+	"sig",
+	"signature",
+	"token",
+	"x-amz-credential",
+	"x-amz-security-token",
+	"x-amz-signature",
+}
+
+// URLs embedded in text, which ends at whitespace, quotes and the backslash
+// that escapes a quote in JSON.
+var urlPattern = regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^\s"'<>\\]+`)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+// RedactURL masks the credentials in a URL: the password of its userinfo, or
+// the user when there is no password as it is then usually a token, and the
+// values of query parameters such as token, password and signature.  The
+// rest of the URL is left as is, even when it does not parse.
+func RedactURL(rawURL string) string {
+	scheme, rest, found := strings.Cut(rawURL, "://")
+	if !found {
+		return rawURL
+	}
+
+	authorityEnd := strings.IndexAny(rest, "/?#")
+	if authorityEnd < 0 {
+		authorityEnd = len(rest)
+	}
+
+	authority, rest := rest[:authorityEnd], rest[authorityEnd:]
+
+	if at := strings.LastIndex(authority, "@"); at >= 0 {
+		user, _, hasPassword := strings.Cut(authority[:at], ":")
+		if hasPassword {
+			authority = user + ":" + Masked + authority[at:]
+		} else {
+			authority = Masked + authority[at:]
+		}
+	}
+
+	path, query, hasQuery := strings.Cut(rest, "?")
+	if !hasQuery {
+		return scheme + "://" + authority + rest
+	}
+
+	query, fragment, hasFragment := strings.Cut(query, "#")
+	parameters := strings.Split(query, "&")
+
+	for index, parameter := range parameters {
+		name, _, hasValue := strings.Cut(parameter, "=")
+		if !hasValue {
+			continue
+		}
+
+		unescaped, err := url.QueryUnescape(name)
+		if err != nil {
+			unescaped = name
+		}
+
+		if slices.Contains(secretQueryParameters, strings.ToLower(unescaped)) {
+			parameters[index] = name + "=" + Masked
+		}
+	}
+
+	result := scheme + "://" + authority + path + "?" + strings.Join(parameters, "&")
+	if hasFragment {
+		result += "#" + fragment
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+// RedactText masks the credentials in the URLs found in text and every
+// occurrence of the given secrets.
+func RedactText(text string, secrets ...string) string {
+	result := urlPattern.ReplaceAllStringFunc(text, func(match string) string {
+		// Punctuation after a URL is more likely to belong to the text.
+		trimmed := strings.TrimRight(match, ".,;:!?)]}")
+
+		return RedactURL(trimmed) + match[len(trimmed):]
+	})
+
+	for _, secret := range secrets {
+		if secret != "" {
+			result = strings.ReplaceAll(result, secret, Masked)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+// RedactError returns an error whose text is that of err with its
+// credentials masked, or nil when err is nil.
+func RedactError(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	text := err.Error()
+
+	redacted := RedactText(text, secrets...)
+	if redacted == text {
+		return err
+	}
+
+	return errors.New(redacted) //nolint:err113
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// The secrets in the options and the files they name, masked wherever they
+// might be shown.
+func (move *BasicMove) secrets() []string {
+	result := []string{move.AMQPPassword, move.HTTPBasicAuthPassword, move.HTTPBearerToken, move.RedactKey}
+	result = append(result, move.fileSecrets.list()...)
+
+	for _, header := range move.HTTPHeaders {
+		_, value, _ := strings.Cut(header, ":")
+		result = append(result, strings.TrimSpace(value))
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+// Mask the credentials in the details of a log message.
+func (move *BasicMove) redactDetails(details []any) []any {
+	secrets := move.secrets()
+	result := make([]any, len(details))
+
+	for index, detail := range details {
+		switch value := detail.(type) {
+		case string:
+			result[index] = RedactText(value, secrets...)
+		case error:
+			result[index] = RedactError(value, secrets...)
+		default:
+			result[index] = detail
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// fileSecrets implementation
+// ----------------------------------------------------------------------------
+
+// Add a secret read from a file, unless it is already known.
+func (secrets *fileSecrets) add(secret string) {
+	if secrets == nil || secret == "" {
+		return
+	}
+
+	secrets.mutex.Lock()
+	defer secrets.mutex.Unlock()
+
+	if !slices.Contains(secrets.values, secret) {
+		secrets.values = append(secrets.values, secret)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func (secrets *fileSecrets) list() []string {
+	if secrets == nil {
+		return nil
+	}
+
+	secrets.mutex.Lock()
+	defer secrets.mutex.Unlock()
+
+	return slices.Clone(secrets.values)
+}
@@ -0,0 +1,345 @@
+package move
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// An sqsProducer sends records to an SQS queue in the order they are read,
+// in batches sent one after the other, with the message group and
+// deduplication IDs FIFO queues need and the operation of records.
+type sqsProducer struct {
+	client   *sqs.Client
+	fifo     bool
+	groupID  string
+	move     *BasicMove
+	name     string
+	queueURL string
+}
+
+const (
+	// The message group ID of records, unless SQSMessageGroupID is set.
+	DefaultSQSMessageGroupID = "{DATA_SOURCE}"
+	// The message group ID of records for which the template is empty.
+	sqsFallbackMessageGroupID = "default"
+	sqsFIFOSuffix             = ".fifo"
+	sqsMaxBatchBytes          = 256 * 1024
+	sqsMaxBatchEntries        = 10
+	sqsMaxIDLength            = 128
+	sqsRetries                = 5
+)
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Write records to an SQS queue, given by an sqs://lookup?queue-name= URL or
+// by the queue's https:// URL.  For a FIFO queue, each record's message group
+// ID comes from SQSMessageGroupID and its deduplication ID from its message
+// ID.
+func (move *BasicMove) writeSQS(ctx context.Context, outputURL string, recordchan chan queues.Record) error {
+	producer, err := move.newSQSProducer(ctx, outputURL)
+	if err != nil {
+		return err
+	}
+
+	return producer.write(ctx, recordchan)
+}
+
+// ----------------------------------------------------------------------------
+
+func (move *BasicMove) newSQSProducer(ctx context.Context, outputURL string) (*sqsProducer, error) {
+	awsConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "config.LoadDefaultConfig")
+	}
+
+	result := &sqsProducer{
+		client:   sqs.NewFromConfig(awsConfig),
+		fifo:     isSQSFIFOQueue(outputURL),
+		groupID:  cmp.Or(move.SQSMessageGroupID, DefaultSQSMessageGroupID),
+		move:     move,
+		name:     RedactURL(outputURL),
+		queueURL: outputURL,
+	}
+
+	if strings.HasPrefix(outputURL, "sqs:") {
+		response, err := result.client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{QueueName: aws.String(sqsQueueName(outputURL))})
+		if err != nil {
+			return nil, wraperror.Errorf(err, "unable to retrieve SQS URL from: %s", result.name)
+		}
+
+		result.queueURL = aws.ToString(response.QueueUrl)
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Send the records in batches of those waiting in the record channel, so that
+// records trickling in, such as from a followed file, are not held back.
+// Records are acknowledged once their batch is sent.
+func (producer *sqsProducer) write(ctx context.Context, recordchan chan queues.Record) error {
+	for record := range recordchan {
+		batch := []types.SendMessageBatchRequestEntry{producer.entry(record)}
+		records := []queues.Record{record}
+		size := sqsEntrySize(batch[0])
+
+	fill:
+		for len(batch) < sqsMaxBatchEntries {
+			select {
+			case next, isOpen := <-recordchan:
+				if !isOpen {
+					break fill
+				}
+
+				entry := producer.entry(next)
+
+				if size+sqsEntrySize(entry) > sqsMaxBatchBytes {
+					err := producer.sendRecords(ctx, batch, records)
+					if err != nil {
+						return err
+					}
+
+					batch, records, size = nil, nil, 0
+				}
+
+				batch = append(batch, entry)
+				records = append(records, next)
+				size += sqsEntrySize(entry)
+			default:
+				break fill
+			}
+		}
+
+		err := producer.sendRecords(ctx, batch, records)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Send a batch and acknowledge its records.
+func (producer *sqsProducer) sendRecords(
+	ctx context.Context,
+	batch []types.SendMessageBatchRequestEntry,
+	records []queues.Record,
+) error {
+	err := producer.send(ctx, batch)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		acknowledge(record, nil)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Send a batch, sending the entries that failed again, with an exponential
+// backoff, until every entry is sent.  Standard queues do not deduplicate, so
+// only the failed entries are sent again.  For FIFO queues, the entries after
+// the first failed one are sent again too, to keep their order; their
+// deduplication IDs keep SQS from queueing them twice.
+func (producer *sqsProducer) send(ctx context.Context, batch []types.SendMessageBatchRequestEntry) error {
+	backoff := defaultHTTPRetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		for index := range batch {
+			batch[index].Id = aws.String(strconv.Itoa(index))
+		}
+
+		response, err := producer.client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+			Entries:  batch,
+			QueueUrl: aws.String(producer.queueURL),
+		})
+		if err != nil {
+			return wraperror.Errorf(RedactError(err), "sending to %s", producer.name)
+		}
+
+		if len(response.Failed) == 0 {
+			return nil
+		}
+
+		failed := make([]bool, len(batch))
+		first := len(batch)
+
+		for _, entry := range response.Failed {
+			if entry.SenderFault {
+				return wraperror.Errorf(errForPackage, "%s rejected a record: %s %s",
+					producer.name, aws.ToString(entry.Code), aws.ToString(entry.Message))
+			}
+
+			index, err := strconv.Atoi(aws.ToString(entry.Id))
+			if err == nil && index >= 0 && index < len(batch) {
+				failed[index] = true
+				first = min(first, index)
+			}
+		}
+
+		cause := wraperror.Errorf(errForPackage, "%s %s", aws.ToString(response.Failed[0].Code), aws.ToString(response.Failed[0].Message))
+
+		if attempt > sqsRetries {
+			return wraperror.Errorf(cause, "sending to %s, gave up after %d attempts", producer.name, attempt)
+		}
+
+		batch = producer.retryEntries(batch, failed, first)
+		producer.move.log(3019, len(batch), producer.name, attempt, sqsRetries, cause)
+
+		select {
+		case <-ctx.Done():
+			return wraperror.Errorf(ctx.Err(), "sending to %s", producer.name)
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, maxHTTPRetryBackoff)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// The entries of a batch to send again: those that failed or, for FIFO
+// queues, those from the first that failed.  When no failed entry is known
+// by its ID, the whole batch is sent again.
+func (producer *sqsProducer) retryEntries(
+	batch []types.SendMessageBatchRequestEntry,
+	failed []bool,
+	first int,
+) []types.SendMessageBatchRequestEntry {
+	switch {
+	case first >= len(batch):
+		return batch
+	case producer.fifo:
+		return batch[first:]
+	}
+
+	var result []types.SendMessageBatchRequestEntry
+
+	for index, entry := range batch {
+		if failed[index] {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+func (producer *sqsProducer) entry(record queues.Record) types.SendMessageBatchRequestEntry {
+	result := types.SendMessageBatchRequestEntry{
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			"MessageID": {
+				DataType:    aws.String("String"),
+				StringValue: aws.String(record.GetMessageID()),
+			},
+		},
+		MessageBody: aws.String(producer.move.messageFor(record)),
+	}
+
+	if szRecord, isSzRecord := record.(*SzRecord); isSzRecord && szRecord.Operation != "" {
+		result.MessageAttributes["Operation"] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(szRecord.Operation),
+		}
+	}
+
+	if producer.fifo {
+		groupID := expandRecordTemplate(producer.groupID, record)
+		if groupID == "" {
+			groupID = sqsFallbackMessageGroupID
+		}
+
+		result.MessageDeduplicationId = aws.String(sqsID(record.GetMessageID()))
+		result.MessageGroupId = aws.String(sqsID(groupID))
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Whether an output URL is that of an SQS FIFO queue, whose name ends in
+// ".fifo".
+func isSQSFIFOQueue(outputURL string) bool {
+	return strings.HasSuffix(sqsQueueName(outputURL), sqsFIFOSuffix)
+}
+
+// ----------------------------------------------------------------------------
+
+// The name of the queue of an sqs://lookup?queue-name= URL or the last
+// element of the path of a queue's https:// URL.
+func sqsQueueName(outputURL string) string {
+	parsedURL, err := url.Parse(outputURL)
+	if err != nil {
+		return ""
+	}
+
+	if parsedURL.Scheme == "sqs" {
+		return parsedURL.Query().Get("queue-name")
+	}
+
+	return parsedURL.Path[strings.LastIndex(parsedURL.Path, "/")+1:]
+}
+
+// ----------------------------------------------------------------------------
+
+// The size SQS counts for an entry against the size of its batch: its body
+// and the names, data types and values of its message attributes.
+func sqsEntrySize(entry types.SendMessageBatchRequestEntry) int {
+	result := len(aws.ToString(entry.MessageBody))
+
+	for name, attribute := range entry.MessageAttributes {
+		result += len(name) + len(aws.ToString(attribute.DataType)) +
+			len(aws.ToString(attribute.StringValue)) + len(attribute.BinaryValue)
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+// A value usable as a message group or deduplication ID: up to 128 printable
+// ASCII characters.  Other values are replaced by their SHA-256 in hex.
+func sqsID(value string) string {
+	valid := value != "" && len(value) <= sqsMaxIDLength
+
+	for index := 0; valid && index < len(value); index++ {
+		valid = value[index] > ' ' && value[index] <= '~'
+	}
+
+	if valid {
+		return value
+	}
+
+	sum := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,394 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test writing to SQS FIFO queues
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Move_sqsFIFO(test *testing.T) {
+	queue := startFakeSQS(test)
+
+	mover := &move.BasicMove{
+		MessageIDStrategy: move.MessageIDRecordKey,
+		OutputURL:         "sqs://lookup?queue-name=records.fifo",
+	}
+	moveToBroker(test, mover, false)
+
+	entries := queue.entries()
+	require.Len(test, entries, 12)
+	require.Equal(test, []string{"records.fifo"}, queue.lookups)
+
+	for index, entry := range entries {
+		require.Contains(test, entry.MessageBody, `"RECORD_ID"`)
+		require.Equal(test, entry.MessageAttributes["MessageID"].StringValue, entry.MessageDeduplicationID)
+
+		if index < 10 {
+			require.Equal(test, "ICIJ", entry.MessageGroupID)
+			require.Equal(test, "ICIJ|"+strconv.Itoa(24000001+index), entry.MessageDeduplicationID)
+		} else {
+			require.Equal(test, "TEST", entry.MessageGroupID)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// The message group ID template and a queue given by its URL.
+func TestBasicMove_Move_sqsFIFOGroupTemplate(test *testing.T) {
+	queue := startFakeSQS(test)
+
+	mover := &move.BasicMove{
+		OutputURL:         "https://sqs.us-east-1.amazonaws.com/000000000000/records.fifo",
+		SQSMessageGroupID: "{DATA_SOURCE}-{RECORD_TYPE}",
+	}
+	moveToBroker(test, mover, false)
+
+	entries := queue.entries()
+	require.Len(test, entries, 12)
+	require.Empty(test, queue.lookups)
+	require.Equal(test, "ICIJ-ADDRESS", entries[0].MessageGroupID)
+	require.NotEmpty(test, entries[0].MessageDeduplicationID)
+}
+
+// ----------------------------------------------------------------------------
+
+// Entries that fail are sent again with those after them, keeping the order.
+func TestBasicMove_Move_sqsFIFORetries(test *testing.T) {
+	queue := startFakeSQS(test)
+	queue.failures = 1
+
+	moveToBroker(test, &move.BasicMove{OutputURL: "sqs://lookup?queue-name=records.fifo"}, false)
+
+	var (
+		bodies []string
+		sent   = map[string]bool{}
+	)
+
+	for _, entry := range queue.entries() {
+		if !sent[entry.MessageDeduplicationID] {
+			sent[entry.MessageDeduplicationID] = true
+
+			bodies = append(bodies, entry.MessageBody)
+		}
+	}
+
+	require.Equal(test, strings.Split(strings.TrimSpace(testGoodData), "\n"), bodies)
+	require.Greater(test, len(queue.batches), 1)
+}
+
+// ----------------------------------------------------------------------------
+
+// Entries SQS rejects as the sender's fault are not sent again.
+func TestBasicMove_Move_sqsFIFORejected(test *testing.T) {
+	queue := startFakeSQS(test)
+	queue.failures = 1
+	queue.senderFault = true
+
+	moveToBroker(test, &move.BasicMove{OutputURL: "sqs://lookup?queue-name=records.fifo"}, true)
+
+	require.Len(test, queue.batches, 1)
+}
+
+// ----------------------------------------------------------------------------
+
+// Standard queues do not deduplicate, so only the entries that fail are sent
+// again.
+func TestBasicMove_Move_sqsRetries(test *testing.T) {
+	queue := startFakeSQS(test)
+	queue.failures = 1
+
+	mover := &move.BasicMove{
+		Operation: []string{"add"},
+		OutputURL: "sqs://lookup?queue-name=records",
+	}
+	moveToBroker(test, mover, false)
+
+	bodies := map[string]int{}
+
+	for _, entry := range queue.entries() {
+		bodies[entry.MessageBody]++
+	}
+
+	require.Len(test, queue.entries(), 13)
+	require.Len(test, bodies, 12)
+}
+
+// ----------------------------------------------------------------------------
+
+// The message attributes count toward the size of a batch along with the
+// bodies.
+func TestBasicMove_Move_sqsBatchBytes(test *testing.T) {
+	queue := startFakeSQS(test)
+
+	// Eight records whose bodies together just fit in a batch.
+	record := `{"DATA_SOURCE":"TEST","RECORD_ID":"%d","NAME_FULL":"%s"}`
+	padding := strings.Repeat("x", 32*1024-1-(len(record)-len("%d%s"))-1)
+
+	var data strings.Builder
+	for index := 1; index <= 8; index++ {
+		data.WriteString(fmt.Sprintf(record, index, padding) + "\n")
+	}
+
+	inputFile, cleanUpTempFile := createTempDataFile(test, data.String(), "jsonl")
+
+	defer cleanUpTempFile()
+
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	mover := &move.BasicMove{
+		InputURL:  "file://" + inputFile,
+		Operation: []string{"add"},
+		OutputURL: "sqs://lookup?queue-name=records",
+	}
+	require.NoError(test, mover.Move(test.Context()))
+	writer.Close()
+
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	for _, batch := range queue.batches {
+		size := 0
+
+		for _, entry := range batch {
+			size += len(entry.MessageBody)
+
+			for name, attribute := range entry.MessageAttributes {
+				size += len(name) + len(attribute.DataType) + len(attribute.StringValue)
+			}
+		}
+
+		require.LessOrEqual(test, size, 256*1024)
+	}
+
+	require.Greater(test, len(queue.batches), 1)
+	require.Len(test, queue.batches[0][0].MessageBody, 32*1024-1)
+}
+
+// ----------------------------------------------------------------------------
+
+// With operations tracked, records sent to a standard queue carry their
+// operation, without the message group and deduplication IDs.
+func TestBasicMove_Move_sqsOperation(test *testing.T) {
+	queue := startFakeSQS(test)
+
+	mover := &move.BasicMove{
+		Operation: []string{"replace"},
+		OutputURL: "sqs://lookup?queue-name=records",
+	}
+	moveToBroker(test, mover, false)
+
+	entries := queue.entries()
+	require.Len(test, entries, 12)
+
+	for _, entry := range entries {
+		require.Equal(test, "replace", entry.MessageAttributes["Operation"].StringValue)
+		require.Empty(test, entry.MessageGroupID)
+		require.Empty(test, entry.MessageDeduplicationID)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// With the JSON envelope, records are sent wrapped in it.
+func TestBasicMove_Move_sqsEnvelope(test *testing.T) {
+	queue := startFakeSQS(test)
+
+	mover := &move.BasicMove{
+		OutputEnvelope: move.OutputEnvelopeJSON,
+		OutputURL:      "sqs://lookup?queue-name=records",
+		RunID:          "move-test",
+	}
+	moveToBroker(test, mover, false)
+
+	entries := queue.entries()
+	require.Len(test, entries, 12)
+
+	for index, entry := range entries {
+		var envelope move.Envelope
+		require.NoError(test, json.Unmarshal([]byte(entry.MessageBody), &envelope))
+		require.Equal(test, index+1, envelope.Line)
+		require.Equal(test, "move-test", envelope.RunID)
+		require.Contains(test, string(envelope.Body), `"RECORD_ID"`)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Without operations tracked, records that carry an operation from an
+// envelope are still sent with it, alongside those the managed producer sends.
+func TestBasicMove_Move_sqsCarriedOperation(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	inputFile, cleanUpTempFile := createTempDataFile(test, testGoodData, "jsonl")
+	defer cleanUpTempFile()
+
+	envelopeFile := filepath.Join(test.TempDir(), "envelope.jsonl")
+
+	mover := &move.BasicMove{
+		InputURL:       "file://" + inputFile,
+		Operation:      []string{"delete"},
+		OutputEnvelope: move.OutputEnvelopeJSON,
+		OutputURL:      "file://" + envelopeFile,
+	}
+	require.NoError(test, mover.Move(test.Context()))
+	writer.Close()
+
+	queue := startFakeSQS(test)
+
+	mover = &move.BasicMove{
+		InputURLs: []string{"file://" + envelopeFile},
+		LogLevel:  "INFO",
+		OutputURL: "sqs://lookup?queue-name=records",
+	}
+	moveToBroker(test, mover, false)
+
+	operations := map[string]int{}
+
+	for _, entry := range queue.entries() {
+		operations[entry.MessageAttributes["Operation"].StringValue]++
+	}
+
+	require.Equal(test, map[string]int{"": 12, "delete": 12}, operations)
+}
+
+// ----------------------------------------------------------------------------
+// Fake SQS
+// ----------------------------------------------------------------------------
+
+// A fakeSQS answers the GetQueueUrl and SendMessageBatch actions of the AWS
+// JSON protocol, which the SDK is pointed at by AWS_ENDPOINT_URL_SQS.
+type fakeSQS struct {
+	batches     [][]fakeSQSEntry
+	failures    int
+	lookups     []string
+	mutex       sync.Mutex
+	rejectAll   bool
+	senderFault bool
+	server      *httptest.Server
+}
+
+type fakeSQSEntry struct {
+	ID                string `json:"Id"`
+	MessageAttributes map[string]struct {
+		DataType    string
+		StringValue string
+	}
+	MessageBody            string
+	MessageDeduplicationID string `json:"MessageDeduplicationId"`
+	MessageGroupID         string `json:"MessageGroupId"`
+}
+
+// ----------------------------------------------------------------------------
+
+func startFakeSQS(t *testing.T) *fakeSQS {
+	t.Helper()
+
+	queue := &fakeSQS{}
+	queue.server = httptest.NewServer(http.HandlerFunc(queue.serveHTTP))
+	t.Cleanup(queue.server.Close)
+
+	dir := t.TempDir()
+	t.Setenv("AWS_ENDPOINT_URL_SQS", queue.server.URL)
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	return queue
+}
+
+// ----------------------------------------------------------------------------
+
+// The entries of all batches, in the order they were sent.
+func (queue *fakeSQS) entries() []fakeSQSEntry {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	var result []fakeSQSEntry
+
+	for _, batch := range queue.batches {
+		result = append(result, batch...)
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+
+func (queue *fakeSQS) serveHTTP(writer http.ResponseWriter, request *http.Request) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	var (
+		input struct {
+			Entries   []fakeSQSEntry
+			QueueName string
+		}
+		output map[string]any
+	)
+
+	if err := json.NewDecoder(request.Body).Decode(&input); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	switch request.Header.Get("X-Amz-Target") {
+	case "AmazonSQS.GetQueueUrl":
+		queue.lookups = append(queue.lookups, input.QueueName)
+		output = map[string]any{"QueueUrl": queue.server.URL + "/000000000000/" + input.QueueName}
+	case "AmazonSQS.SendMessageBatch":
+		queue.batches = append(queue.batches, input.Entries)
+
+		successful := []map[string]any{}
+		failed := []map[string]any{}
+
+		for index, entry := range input.Entries {
+			if queue.rejectAll || (queue.failures > 0 && index == 1) {
+				queue.failures--
+
+				failed = append(failed, map[string]any{
+					"Code": "InternalError", "Id": entry.ID, "Message": "try again", "SenderFault": queue.senderFault,
+				})
+
+				continue
+			}
+
+			sum := md5.Sum([]byte(entry.MessageBody)) //nolint:gosec
+			successful = append(successful, map[string]any{
+				"Id": entry.ID, "MD5OfMessageBody": hex.EncodeToString(sum[:]), "MessageId": entry.ID,
+			})
+		}
+
+		output = map[string]any{"Failed": failed, "Successful": successful}
+	default:
+		writer.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	_ = json.NewEncoder(writer).Encode(output)
+}
@@ -0,0 +1,281 @@
+package move
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Statistics is a profile of the records found in an input.
+type Statistics struct {
+	Attributes        map[string]int `json:"attributes"`
+	DataSources       map[string]int `json:"dataSources"`
+	Malformed         int            `json:"malformed"`
+	MissingDataSource int            `json:"missingDataSource"`
+	MissingRecordID   int            `json:"missingRecordId"`
+	Records           int            `json:"records"`
+	Sizes             SizeStatistics `json:"sizes"`
+}
+
+// SizeStatistics describes the distribution of record sizes, in bytes.
+type SizeStatistics struct {
+	Buckets []SizeBucket `json:"buckets"`
+	Max     int          `json:"max"`
+	Mean    float64      `json:"mean"`
+	Min     int          `json:"min"`
+	Total   int64        `json:"total"`
+}
+
+// SizeBucket counts the records whose size is at most UpperBound bytes and
+// larger than the previous bucket's UpperBound.  An UpperBound of 0 means
+// the bucket is unbounded.
+type SizeBucket struct {
+	Count      int `json:"count"`
+	UpperBound int `json:"upperBound"`
+}
+
+const (
+	StatsFormatJSON  = "json"
+	StatsFormatTable = "table"
+)
+
+// Upper bounds of the record size buckets; the last bucket is unbounded.
+var sizeBucketBounds = []int{256, 1024, 4096, 16384, 65536, 0}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+// NewStatistics returns an empty profile.
+func NewStatistics() *Statistics {
+	buckets := make([]SizeBucket, len(sizeBucketBounds))
+	for i, bound := range sizeBucketBounds {
+		buckets[i].UpperBound = bound
+	}
+
+	return &Statistics{
+		Attributes:  map[string]int{},
+		DataSources: map[string]int{},
+		Sizes:       SizeStatistics{Buckets: buckets},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+// Stats reads every record of the input and returns a profile of the records.
+// Options are checked as they are by Move but, unlike Move, records are not
+// validated so that the profile includes the records Move would reject.
+func (move *BasicMove) Stats(ctx context.Context) (*Statistics, error) {
+	var (
+		readErr   error
+		waitGroup sync.WaitGroup
+	)
+
+	err := move.validateOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	statistics := NewStatistics()
+	recordchan := make(chan queues.Record, numChannels)
+
+	move.skipValidation = true
+
+	waitGroup.Add(1)
+
+	go func() {
+		defer waitGroup.Done()
+
+		readErr = move.read(ctx, recordchan)
+	}()
+
+	for record := range recordchan {
+		statistics.Add(record.GetMessage())
+	}
+
+	waitGroup.Wait()
+
+	closeErr := move.closeDedupe()
+	if readErr != nil {
+		return statistics, readErr
+	}
+
+	return statistics, closeErr
+}
+
+// ----------------------------------------------------------------------------
+
+// Add a single JSON line to the profile.
+func (statistics *Statistics) Add(line string) {
+	statistics.Records++
+	statistics.addSize(len(line))
+
+	var attributes map[string]any
+
+	err := json.Unmarshal([]byte(line), &attributes)
+	if err != nil {
+		statistics.Malformed++
+
+		return
+	}
+
+	dataSource, isString := attributes["DATA_SOURCE"].(string)
+	if !isString || len(dataSource) == 0 {
+		statistics.MissingDataSource++
+	} else {
+		statistics.DataSources[dataSource]++
+	}
+
+	if !hasValue(attributes["RECORD_ID"]) {
+		statistics.MissingRecordID++
+	}
+
+	for name, value := range attributes {
+		statistics.Attributes[name]++
+
+		// Count the attributes of feature lists, e.g. NAMES.NAME_FULL.
+		list, isList := value.([]any)
+		if !isList {
+			continue
+		}
+
+		for _, item := range list {
+			feature, isObject := item.(map[string]any)
+			if !isObject {
+				continue
+			}
+
+			for featureName := range feature {
+				statistics.Attributes[name+"."+featureName]++
+			}
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// WriteJSON writes the profile as an indented JSON document.
+func (statistics *Statistics) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(statistics)
+
+	return wraperror.Errorf(err, "json.Encode")
+}
+
+// ----------------------------------------------------------------------------
+
+// WriteTable writes the profile as human readable tables.
+func (statistics *Statistics) WriteTable(writer io.Writer) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintf(table, "RECORDS\t%d\n", statistics.Records)
+	fmt.Fprintf(table, "MALFORMED\t%d\n", statistics.Malformed)
+	fmt.Fprintf(table, "MISSING DATA_SOURCE\t%d\n", statistics.MissingDataSource)
+	fmt.Fprintf(table, "MISSING RECORD_ID\t%d\n", statistics.MissingRecordID)
+
+	fmt.Fprintf(table, "\nDATA_SOURCE\tRECORDS\n")
+
+	for _, name := range sortedByCount(statistics.DataSources) {
+		fmt.Fprintf(table, "%s\t%d\n", name, statistics.DataSources[name])
+	}
+
+	fmt.Fprintf(table, "\nATTRIBUTE\tRECORDS\n")
+
+	for _, name := range sortedByCount(statistics.Attributes) {
+		fmt.Fprintf(table, "%s\t%d\n", name, statistics.Attributes[name])
+	}
+
+	fmt.Fprintf(table, "\nSIZE (BYTES)\tRECORDS\n")
+
+	lowerBound := 0
+	for _, bucket := range statistics.Sizes.Buckets {
+		if bucket.UpperBound == 0 {
+			fmt.Fprintf(table, "> %d\t%d\n", lowerBound, bucket.Count)
+		} else {
+			fmt.Fprintf(table, "<= %d\t%d\n", bucket.UpperBound, bucket.Count)
+		}
+
+		lowerBound = bucket.UpperBound
+	}
+
+	fmt.Fprintf(
+		table,
+		"min %d, max %d, mean %.1f\n",
+		statistics.Sizes.Min,
+		statistics.Sizes.Max,
+		statistics.Sizes.Mean,
+	)
+
+	err := table.Flush()
+
+	return wraperror.Errorf(err, "tabwriter.Flush")
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+func (statistics *Statistics) addSize(size int) {
+	sizes := &statistics.Sizes
+
+	if statistics.Records == 1 || size < sizes.Min {
+		sizes.Min = size
+	}
+
+	if size > sizes.Max {
+		sizes.Max = size
+	}
+
+	sizes.Total += int64(size)
+	sizes.Mean = float64(sizes.Total) / float64(statistics.Records)
+
+	for i, bucket := range sizes.Buckets {
+		if bucket.UpperBound == 0 || size <= bucket.UpperBound {
+			sizes.Buckets[i].Count++
+
+			break
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Report whether a JSON value is present and non-empty.
+func hasValue(value any) bool {
+	switch typedValue := value.(type) {
+	case nil:
+		return false
+	case string:
+		return len(typedValue) > 0
+	default:
+		return true
+	}
+}
+
+// Names ordered by descending count, ties broken alphabetically.
+func sortedByCount(counts map[string]int) []string {
+	names := slices.Sorted(maps.Keys(counts))
+	slices.SortStableFunc(names, func(a, b string) int {
+		return counts[b] - counts[a]
+	})
+
+	return names
+}
@@ -0,0 +1,166 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test Stats method
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Stats(test *testing.T) {
+	ctx := test.Context()
+
+	filename, cleanUpTempFile := createTempDataFile(test, testBadData, "jsonl")
+	test.Cleanup(cleanUpTempFile)
+
+	mover := &move.BasicMove{
+		InputURL: "file://" + filename,
+	}
+
+	statistics, err := mover.Stats(ctx)
+	require.NoError(test, err)
+	require.Equal(test, 16, statistics.Records)
+	require.Equal(test, 2, statistics.Malformed)
+	require.Equal(test, 1, statistics.MissingDataSource)
+	require.Equal(test, 1, statistics.MissingRecordID)
+	require.Equal(test, 11, statistics.DataSources["ICIJ"])
+	require.Equal(test, 2, statistics.DataSources["TEST"])
+	require.Equal(test, 12, statistics.Attributes["COUNTRIES"])
+	require.Equal(test, 12, statistics.Attributes["COUNTRIES.COUNTRY_OF_ASSOCIATION"])
+
+	bucketTotal := 0
+	for _, bucket := range statistics.Sizes.Buckets {
+		bucketTotal += bucket.Count
+	}
+
+	require.Equal(test, statistics.Records, bucketTotal)
+	require.LessOrEqual(test, statistics.Sizes.Min, statistics.Sizes.Max)
+}
+
+func TestBasicMove_Stats_gzip(test *testing.T) {
+	ctx := test.Context()
+
+	filename, cleanUpTempFile := createTempGZIPDataFile(test, testGoodData)
+	test.Cleanup(cleanUpTempFile)
+
+	mover := &move.BasicMove{
+		InputURL:  "file://" + filename,
+		RecordMax: 5,
+	}
+
+	statistics, err := mover.Stats(ctx)
+	require.NoError(test, err)
+	require.Equal(test, 5, statistics.Records)
+	require.Equal(test, 5, statistics.DataSources["ICIJ"])
+}
+
+func TestBasicMove_Stats_bad_file_name(test *testing.T) {
+	ctx := test.Context()
+
+	mover := &move.BasicMove{
+		InputURL: "file:///bad.jsonl",
+	}
+
+	_, err := mover.Stats(ctx)
+	require.Error(test, err)
+}
+
+func TestBasicMove_Stats_bad_stats_format(test *testing.T) {
+	ctx := test.Context()
+
+	// The format is checked before the input is read.
+	mover := &move.BasicMove{
+		InputURL:    "file:///bad.jsonl",
+		StatsFormat: "xml",
+	}
+
+	_, err := mover.Stats(ctx)
+	require.ErrorContains(test, err, "unknown stats format: xml")
+}
+
+func TestBasicMove_Stats_encrypted(test *testing.T) {
+	ctx := test.Context()
+
+	dir := test.TempDir()
+	identity, identityFile := createAgeIdentity(test, dir)
+
+	inputFile := filepath.Join(dir, "records.jsonl.age")
+	file, err := os.Create(inputFile)
+	require.NoError(test, err)
+
+	plaintext, err := age.Encrypt(file, identity.Recipient())
+	require.NoError(test, err)
+
+	_, err = io.WriteString(plaintext, testGoodData)
+	require.NoError(test, err)
+	require.NoError(test, plaintext.Close())
+	require.NoError(test, file.Close())
+
+	mover := &move.BasicMove{
+		AgeIdentityFile: identityFile,
+		InputURL:        "file://" + inputFile,
+	}
+
+	statistics, err := mover.Stats(ctx)
+	require.NoError(test, err)
+	require.Equal(test, 12, statistics.Records)
+}
+
+// ----------------------------------------------------------------------------
+// test Statistics methods
+// ----------------------------------------------------------------------------
+
+func TestStatistics_Add(test *testing.T) {
+	statistics := move.NewStatistics()
+	statistics.Add(`{"DATA_SOURCE": "A", "RECORD_ID": "1"}`)
+	statistics.Add(`{"DATA_SOURCE": "A", "RECORD_ID": ""}`)
+	statistics.Add(`{"DATA_SOURCE": "B", "RECORD_ID": 3}`)
+	statistics.Add(`not json`)
+
+	require.Equal(test, 4, statistics.Records)
+	require.Equal(test, 1, statistics.Malformed)
+	require.Equal(test, 1, statistics.MissingRecordID)
+	require.Equal(test, 2, statistics.DataSources["A"])
+	require.Equal(test, 3, statistics.Attributes["RECORD_ID"])
+	require.Equal(test, len(`not json`), statistics.Sizes.Min)
+}
+
+func TestStatistics_WriteJSON(test *testing.T) {
+	statistics := move.NewStatistics()
+	statistics.Add(`{"DATA_SOURCE": "A", "RECORD_ID": "1"}`)
+
+	var buffer bytes.Buffer
+
+	err := statistics.WriteJSON(&buffer)
+	require.NoError(test, err)
+	require.Contains(test, buffer.String(), `"dataSources": {`)
+	require.Contains(test, buffer.String(), `"missingRecordId": 0`)
+}
+
+func TestStatistics_WriteTable(test *testing.T) {
+	statistics := move.NewStatistics()
+	statistics.Add(`{"DATA_SOURCE": "A", "RECORD_ID": "1"}`)
+	statistics.Add(`{"DATA_SOURCE": "B", "RECORD_ID": "2"}`)
+	statistics.Add(`{"DATA_SOURCE": "B", "RECORD_ID": "3"}`)
+
+	var buffer bytes.Buffer
+
+	err := statistics.WriteTable(&buffer)
+	require.NoError(test, err)
+
+	actual := buffer.String()
+	require.Contains(test, actual, "RECORDS")
+	require.Less(test, strings.Index(actual, "B "), strings.Index(actual, "A "))
+}
@@ -1,7 +1,12 @@
 package move
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/senzing-garage/go-queueing/queues"
 )
@@ -14,16 +19,60 @@ import (
 var _ queues.Record = (*SzRecord)(nil)
 
 type SzRecord struct {
-	Body   string
-	ID     int
-	Source string
+	Body string
+	// The delivery of the input the record was read from, when the writer
+	// must acknowledge it.
+	delivery *delivery
+	ID       int
+	// When the record was read.
+	IngestedAt        time.Time
+	MessageIDStrategy string
+	// OperationAdd, OperationReplace or OperationDelete, or empty when the
+	// operations of records are not tracked.
+	Operation string
+	Source    string
 }
 
+// Strategies for SzRecord.GetMessageID.
+const (
+	// "DATA_SOURCE|RECORD_ID", stable for as long as the record keeps its key.
+	MessageIDRecordKey = "record-key"
+	// SHA-256 of the record with its keys sorted, stable for identical content.
+	MessageIDContentHash = "content-hash"
+	// "Source-ID", where ID is the line number.  The default.
+	MessageIDSourceLine = "source-line"
+)
+
 func (r *SzRecord) GetMessage() string {
 	return r.Body
 }
 
+// GetMessageID returns an identifier for the message according to the
+// record's MessageIDStrategy.  If the body cannot be parsed, the source-line
+// identifier is returned.
 func (r *SzRecord) GetMessageID() string {
-	// IMPROVE: meaningful or random MessageId?
+	switch strings.ToLower(r.MessageIDStrategy) {
+	case MessageIDRecordKey:
+		var key struct {
+			DataSource string `json:"DATA_SOURCE"`
+			RecordID   string `json:"RECORD_ID"`
+		}
+
+		err := json.Unmarshal([]byte(r.Body), &key)
+		if err == nil && key.DataSource != "" && key.RecordID != "" {
+			return key.DataSource + "|" + key.RecordID
+		}
+	case MessageIDContentHash:
+		attributes, err := parseRecordBody(r.Body)
+		if err == nil {
+			body, err := formatRecordBody(attributes)
+			if err == nil {
+				sum := sha256.Sum256([]byte(body))
+
+				return hex.EncodeToString(sum[:])
+			}
+		}
+	}
+
 	return fmt.Sprintf("%s-%d", r.Source, r.ID)
 }
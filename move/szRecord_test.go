@@ -1,16 +1,20 @@
 package move_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/senzing-garage/go-queueing/queues"
 	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_szRecord_GetMessageId(test *testing.T) {
 	type fields struct {
-		body   string
-		id     int
-		source string
+		body              string
+		id                int
+		messageIDStrategy string
+		source            string
 	}
 
 	testCases := []struct {
@@ -23,13 +27,64 @@ func Test_szRecord_GetMessageId(test *testing.T) {
 			fields:   fields{body: "", id: 0, source: "file.jsonl"},
 			expected: "file.jsonl-0",
 		},
+		{
+			name: "test source-line",
+			fields: fields{
+				body:              `{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}`,
+				id:                12,
+				messageIDStrategy: move.MessageIDSourceLine,
+				source:            "/data/file.jsonl",
+			},
+			expected: "/data/file.jsonl-12",
+		},
+		{
+			name: "test record-key",
+			fields: fields{
+				body:              `{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}`,
+				id:                12,
+				messageIDStrategy: move.MessageIDRecordKey,
+				source:            "/data/file.jsonl",
+			},
+			expected: "TEST|1",
+		},
+		{
+			name: "test record-key, not JSON",
+			fields: fields{
+				body:              `not JSON`,
+				id:                12,
+				messageIDStrategy: move.MessageIDRecordKey,
+				source:            "/data/file.jsonl",
+			},
+			expected: "/data/file.jsonl-12",
+		},
+		{
+			name: "test content-hash",
+			fields: fields{
+				body:              `{"RECORD_ID": "1", "DATA_SOURCE": "TEST"}`,
+				id:                12,
+				messageIDStrategy: move.MessageIDContentHash,
+				source:            "/data/file.jsonl",
+			},
+			expected: "a74c15a41fd8e08c56d31d8a38eb298e91e812fe46b2a8d94365873340387d2a",
+		},
+		{
+			name: "test content-hash, key order does not matter",
+			fields: fields{
+				body:              `{"DATA_SOURCE":"TEST","RECORD_ID":"1"}`,
+				id:                7,
+				messageIDStrategy: move.MessageIDContentHash,
+				source:            "/moved/file.jsonl",
+			},
+			expected: "a74c15a41fd8e08c56d31d8a38eb298e91e812fe46b2a8d94365873340387d2a",
+		},
 	}
 	for _, testCase := range testCases {
 		test.Run(testCase.name, func(test *testing.T) {
 			record := &move.SzRecord{
-				Body:   testCase.fields.body,
-				ID:     testCase.fields.id,
-				Source: testCase.fields.source,
+				Body:              testCase.fields.body,
+				ID:                testCase.fields.id,
+				MessageIDStrategy: testCase.fields.messageIDStrategy,
+				Source:            testCase.fields.source,
 			}
 			if actual := record.GetMessageID(); actual != testCase.expected {
 				test.Errorf("szRecord.GetMessageID() = %v, want %v", actual, testCase.expected)
@@ -37,3 +92,24 @@ func Test_szRecord_GetMessageId(test *testing.T) {
 		})
 	}
 }
+
+func TestBasicMove_processJSONL_message_id_strategy(test *testing.T) {
+	recordchan := make(chan queues.Record, 1)
+
+	mover := &move.BasicMove{
+		MessageIDStrategy: move.MessageIDRecordKey,
+	}
+	mover.ProcessJSONL("file.jsonl", strings.NewReader(`{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}`), recordchan)
+
+	record := <-recordchan
+	require.Equal(test, "TEST|1", record.GetMessageID())
+}
+
+func TestBasicMove_Move_unknown_message_id_strategy(test *testing.T) {
+	mover := &move.BasicMove{
+		MessageIDStrategy: "bad",
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+}
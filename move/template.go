@@ -0,0 +1,39 @@
+package move
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// References to record attributes in templates such as the AMQP routing key
+// and the SQS message group ID, written {NAME}.
+var recordTemplatePattern = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Replace the {NAME} references in a template with the values of the
+// record's attributes.  Attributes the record does not have are replaced by
+// nothing.
+func expandRecordTemplate(template string, record queues.Record) string {
+	attributes, err := parseRecordBody(record.GetMessage())
+	if err != nil {
+		attributes = map[string]any{}
+	}
+
+	return recordTemplatePattern.ReplaceAllStringFunc(template, func(reference string) string {
+		value, found := attributes[recordTemplatePattern.FindStringSubmatch(reference)[1]]
+		if !found || value == nil {
+			return ""
+		}
+
+		return fmt.Sprint(value)
+	})
+}
@@ -0,0 +1,53 @@
+package move
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The TLS configuration for HTTPS inputs and AMQPS outputs: CAs trusted in
+// addition to the system's and a client certificate for mutual TLS.
+func newTLSConfig(caCertFile string, clientCertFile string, clientKeyFile string) (*tls.Config, error) {
+	result := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caCertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		pem, err := os.ReadFile(filepath.Clean(caCertFile))
+		if err != nil {
+			return nil, wraperror.Errorf(err, "os.ReadFile")
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, wraperror.Errorf(errForPackage, "no certificates found in %s", caCertFile)
+		}
+
+		result.RootCAs = pool
+	}
+
+	if clientCertFile != "" || clientKeyFile != "" {
+		if clientCertFile == "" || clientKeyFile == "" {
+			return nil, wraperror.Errorf(errForPackage, "a client certificate needs both a certificate and a key file")
+		}
+
+		certificate, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "tls.LoadX509KeyPair")
+		}
+
+		result.Certificates = []tls.Certificate{certificate}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,401 @@
+package move
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"go.yaml.in/yaml/v3"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Transform is an ordered list of field mapping rules, read from a YAML or
+// JSON rules file, that is applied to every record before it is validated.
+type Transform struct {
+	Rules []TransformRule `yaml:"rules"`
+}
+
+// TransformRule is a single step of a Transform.  Exactly one of the fields
+// is set.
+type TransformRule struct {
+	Concat    *ConcatRule    `yaml:"concat"`
+	Copy      *FieldPair     `yaml:"copy"`
+	Drop      []string       `yaml:"drop"`
+	Nest      *NestRule      `yaml:"nest"`
+	Rename    *FieldPair     `yaml:"rename"`
+	Set       map[string]any `yaml:"set"`
+	SplitName *SplitNameRule `yaml:"split_name"`
+}
+
+// FieldPair names the source and target attributes of a copy or rename.
+type FieldPair struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// ConcatRule joins the non-empty values of several attributes into one.
+type ConcatRule struct {
+	From      []string `yaml:"from"`
+	Separator *string  `yaml:"separator"`
+	To        string   `yaml:"to"`
+}
+
+// NestRule moves flat attributes into a new object appended to a feature
+// list.  Fields maps the attribute names in the object to the flat
+// attribute names, e.g. NAME_FIRST: FIRST_NAME.
+type NestRule struct {
+	Fields map[string]string `yaml:"fields"`
+	To     string            `yaml:"to"`
+}
+
+// SplitNameRule splits a full name into its parts.  "Last, First Middle" and
+// "First Middle Last" are recognized.
+type SplitNameRule struct {
+	First  string `yaml:"first"`
+	From   string `yaml:"from"`
+	Last   string `yaml:"last"`
+	Middle string `yaml:"middle"`
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+// LoadTransform reads a rules file.
+func LoadTransform(fileName string) (*Transform, error) {
+	content, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "os.ReadFile")
+	}
+
+	result, err := ParseTransform(content)
+
+	return result, wraperror.Errorf(err, "transform file %s", fileName)
+}
+
+// ParseTransform parses rules in YAML or JSON, which is a subset of YAML.
+func ParseTransform(content []byte) (*Transform, error) {
+	result := &Transform{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(result)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "yaml.Decode")
+	}
+
+	for index, rule := range result.Rules {
+		err = rule.check()
+		if err != nil {
+			return nil, wraperror.Errorf(err, "rule %d", index+1)
+		}
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+// Apply the rules, in order, to a record's attributes.  Reports whether the
+// attributes were changed.
+func (transform *Transform) Apply(attributes map[string]any) (bool, error) {
+	changed := false
+
+	for index, rule := range transform.Rules {
+		ruleChanged, err := rule.apply(attributes)
+		if err != nil {
+			return changed, wraperror.Errorf(err, "rule %d (%s)", index+1, rule.name())
+		}
+
+		changed = changed || ruleChanged
+	}
+
+	return changed, nil
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Apply the --transform-file rules.
+func (move *BasicMove) applyTransform(attributes map[string]any) (bool, error) {
+	if move.TransformFile == "" {
+		return false, nil
+	}
+
+	if move.transform == nil {
+		transform, err := LoadTransform(move.TransformFile)
+		if err != nil {
+			return false, err
+		}
+
+		move.transform = transform
+	}
+
+	return move.transform.Apply(attributes)
+}
+
+// ----------------------------------------------------------------------------
+
+// Check that exactly one action is set and that it is complete.
+func (rule *TransformRule) check() error {
+	actions := 0
+
+	for _, isSet := range []bool{
+		rule.Concat != nil,
+		rule.Copy != nil,
+		rule.Drop != nil,
+		rule.Nest != nil,
+		rule.Rename != nil,
+		rule.Set != nil,
+		rule.SplitName != nil,
+	} {
+		if isSet {
+			actions++
+		}
+	}
+
+	if actions != 1 {
+		return wraperror.Errorf(errForPackage, "must have exactly one action, found %d", actions)
+	}
+
+	var complete bool
+
+	switch {
+	case rule.Concat != nil:
+		complete = len(rule.Concat.From) > 0 && rule.Concat.To != ""
+	case rule.Copy != nil:
+		complete = rule.Copy.From != "" && rule.Copy.To != ""
+	case rule.Nest != nil:
+		complete = len(rule.Nest.Fields) > 0 && rule.Nest.To != ""
+	case rule.Rename != nil:
+		complete = rule.Rename.From != "" && rule.Rename.To != ""
+	case rule.SplitName != nil:
+		splitName := rule.SplitName
+		complete = splitName.From != "" && (splitName.First != "" || splitName.Last != "" || splitName.Middle != "")
+	default:
+		complete = true
+	}
+
+	if !complete {
+		return wraperror.Errorf(errForPackage, "%s is missing a source or target attribute", rule.name())
+	}
+
+	return nil
+}
+
+func (rule *TransformRule) name() string {
+	switch {
+	case rule.Concat != nil:
+		return "concat"
+	case rule.Copy != nil:
+		return "copy"
+	case rule.Drop != nil:
+		return "drop"
+	case rule.Nest != nil:
+		return "nest"
+	case rule.Rename != nil:
+		return "rename"
+	case rule.SplitName != nil:
+		return "split_name"
+	default:
+		return "set"
+	}
+}
+
+func (rule *TransformRule) apply(attributes map[string]any) (bool, error) {
+	switch {
+	case rule.Concat != nil:
+		return applyConcat(rule.Concat, attributes)
+	case rule.Copy != nil:
+		return applyCopy(rule.Copy, attributes, false), nil
+	case rule.Drop != nil:
+		return applyDrop(rule.Drop, attributes), nil
+	case rule.Nest != nil:
+		return applyNest(rule.Nest, attributes)
+	case rule.Rename != nil:
+		return applyCopy(rule.Rename, attributes, true), nil
+	case rule.SplitName != nil:
+		return applySplitName(rule.SplitName, attributes)
+	default:
+		for name, value := range rule.Set {
+			attributes[name] = value
+		}
+
+		return len(rule.Set) > 0, nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func applyConcat(rule *ConcatRule, attributes map[string]any) (bool, error) {
+	separator := " "
+	if rule.Separator != nil {
+		separator = *rule.Separator
+	}
+
+	var parts []string
+
+	for _, name := range rule.From {
+		value, err := scalarText(name, attributes[name])
+		if err != nil {
+			return false, err
+		}
+
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+
+	if len(parts) == 0 {
+		return false, nil
+	}
+
+	attributes[rule.To] = strings.Join(parts, separator)
+
+	return true, nil
+}
+
+// Copy, or rename when remove is set, an attribute.
+func applyCopy(pair *FieldPair, attributes map[string]any, remove bool) bool {
+	value, isPresent := attributes[pair.From]
+	if !isPresent {
+		return false
+	}
+
+	if remove {
+		delete(attributes, pair.From)
+	}
+
+	attributes[pair.To] = value
+
+	return true
+}
+
+func applyDrop(names []string, attributes map[string]any) bool {
+	changed := false
+
+	for _, name := range names {
+		if _, isPresent := attributes[name]; isPresent {
+			delete(attributes, name)
+
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func applyNest(rule *NestRule, attributes map[string]any) (bool, error) {
+	feature := map[string]any{}
+
+	for target, source := range rule.Fields {
+		value, isPresent := attributes[source]
+		if !isPresent {
+			continue
+		}
+
+		delete(attributes, source)
+
+		if hasValue(value) {
+			feature[target] = value
+		}
+	}
+
+	if len(feature) == 0 {
+		return false, nil
+	}
+
+	var list []any
+
+	if existing, isPresent := attributes[rule.To]; isPresent {
+		var isList bool
+
+		list, isList = existing.([]any)
+		if !isList {
+			return false, wraperror.Errorf(errForPackage, "%s is not a list", rule.To)
+		}
+	}
+
+	attributes[rule.To] = append(list, feature)
+
+	return true, nil
+}
+
+func applySplitName(rule *SplitNameRule, attributes map[string]any) (bool, error) {
+	value, isPresent := attributes[rule.From]
+	if !isPresent {
+		return false, nil
+	}
+
+	fullName, isString := value.(string)
+	if !isString {
+		return false, wraperror.Errorf(errForPackage, "%s is not a string", rule.From)
+	}
+
+	first, middle, last := splitName(fullName)
+
+	changed := false
+
+	for _, part := range []struct{ name, value string }{
+		{rule.First, first},
+		{rule.Middle, middle},
+		{rule.Last, last},
+	} {
+		if part.name != "" && part.value != "" {
+			attributes[part.name] = part.value
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+// Split "Last, First Middle" or "First Middle Last".  A single word is taken
+// to be the last name.
+func splitName(fullName string) (string, string, string) {
+	if last, rest, hasComma := strings.Cut(fullName, ","); hasComma {
+		words := strings.Fields(rest)
+		if len(words) == 0 {
+			return "", "", strings.TrimSpace(last)
+		}
+
+		return words[0], strings.Join(words[1:], " "), strings.TrimSpace(last)
+	}
+
+	words := strings.Fields(fullName)
+
+	switch len(words) {
+	case 0:
+		return "", "", ""
+	case 1:
+		return "", "", words[0]
+	default:
+		return words[0], strings.Join(words[1:len(words)-1], " "), words[len(words)-1]
+	}
+}
+
+// The text of a string or number attribute.  Missing attributes are empty.
+func scalarText(name string, value any) (string, error) {
+	switch typedValue := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typedValue, nil
+	case json.Number:
+		return typedValue.String(), nil
+	default:
+		return "", wraperror.Errorf(errForPackage, "%s is not a string or a number", name)
+	}
+}
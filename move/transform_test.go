@@ -0,0 +1,188 @@
+package move_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+var testTransformRules = `
+rules:
+  - rename: {from: FULLNAME, to: NAME_FULL}
+  - split_name: {from: NAME_FULL, first: NAME_FIRST, middle: NAME_MIDDLE, last: NAME_LAST}
+  - drop: [NAME_FULL, INTERNAL_ID]
+  - copy: {from: CUSTOMER_NUMBER, to: RECORD_ID}
+  - concat: {from: [STREET, UNIT], to: ADDR_LINE1, separator: ", "}
+  - nest:
+      to: ADDRESSES
+      fields: {ADDR_LINE1: ADDR_LINE1, ADDR_CITY: CITY}
+  - drop: [STREET, UNIT]
+  - set: {DATA_SOURCE: CUSTOMERS}
+`
+
+func TestTransform_Apply(test *testing.T) {
+	transform, err := move.ParseTransform([]byte(testTransformRules))
+	require.NoError(test, err)
+
+	testCases := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{
+			name: "all rules",
+			line: `{"FULLNAME": "Robert James Smith", "INTERNAL_ID": 1, "CUSTOMER_NUMBER": 1001, ` +
+				`"STREET": "1 Main St", "UNIT": "Apt 2", "CITY": "Springfield"}`,
+			expected: `{"ADDRESSES":[{"ADDR_CITY":"Springfield","ADDR_LINE1":"1 Main St, Apt 2"}],` +
+				`"CUSTOMER_NUMBER":1001,"DATA_SOURCE":"CUSTOMERS","NAME_FIRST":"Robert","NAME_LAST":"Smith",` +
+				`"NAME_MIDDLE":"James","RECORD_ID":1001}`,
+		},
+		{
+			name:     "last name first",
+			line:     `{"FULLNAME": "Smith, Mary", "CUSTOMER_NUMBER": "C2"}`,
+			expected: `{"CUSTOMER_NUMBER":"C2","DATA_SOURCE":"CUSTOMERS","NAME_FIRST":"Mary","NAME_LAST":"Smith","RECORD_ID":"C2"}`,
+		},
+		{
+			name:     "missing attributes",
+			line:     `{"RECORD_ID": "3"}`,
+			expected: `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"3"}`,
+		},
+		{
+			name:     "existing feature list",
+			line:     `{"ADDRESSES": [{"ADDR_FULL": "2 Oak Ave"}], "CITY": "Shelbyville"}`,
+			expected: `{"ADDRESSES":[{"ADDR_FULL":"2 Oak Ave"},{"ADDR_CITY":"Shelbyville"}],"DATA_SOURCE":"CUSTOMERS"}`,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			attributes := decodeAttributes(test, testCase.line)
+
+			changed, err := transform.Apply(attributes)
+			require.NoError(test, err)
+			require.True(test, changed)
+
+			actual, err := json.Marshal(attributes)
+			require.NoError(test, err)
+			require.JSONEq(test, testCase.expected, string(actual))
+		})
+	}
+}
+
+func TestBasicMove_processJSONL_transform(test *testing.T) {
+	data := `{"FULLNAME": "Robert Smith", "CUSTOMER_NUMBER": "1001"}
+{"FULLNAME": ["Robert Smith"], "CUSTOMER_NUMBER": "1002"}
+`
+	recordchan := make(chan queues.Record, 2)
+
+	mover := &move.BasicMove{
+		TransformFile: writeTransformFile(test, testTransformRules),
+	}
+	mover.ProcessJSONL("test.jsonl", strings.NewReader(data), recordchan)
+
+	var actual []string
+	for record := range recordchan {
+		actual = append(actual, record.GetMessage())
+	}
+
+	// The second record fails the split_name rule and is rejected.
+	require.Len(test, actual, 1)
+	require.JSONEq(
+		test,
+		`{"CUSTOMER_NUMBER":"1001","DATA_SOURCE":"CUSTOMERS","NAME_FIRST":"Robert","NAME_LAST":"Smith","RECORD_ID":"1001"}`,
+		actual[0],
+	)
+}
+
+func TestTransform_Apply_errors(test *testing.T) {
+	testCases := []struct {
+		name  string
+		rules string
+		line  string
+	}{
+		{
+			name:  "split a list",
+			rules: `{"rules": [{"split_name": {"from": "NAMES", "last": "NAME_LAST"}}]}`,
+			line:  `{"NAMES": []}`,
+		},
+		{
+			name:  "concat an object",
+			rules: `{"rules": [{"concat": {"from": ["A", "B"], "to": "C"}}]}`,
+			line:  `{"A": "x", "B": {"y": 1}}`,
+		},
+		{
+			name:  "nest into a string",
+			rules: `{"rules": [{"nest": {"to": "NAMES", "fields": {"NAME_FULL": "NAME"}}}]}`,
+			line:  `{"NAMES": "x", "NAME": "Bob"}`,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			transform, err := move.ParseTransform([]byte(testCase.rules))
+			require.NoError(test, err)
+
+			attributes := decodeAttributes(test, testCase.line)
+
+			_, err = transform.Apply(attributes)
+			require.Error(test, err)
+		})
+	}
+}
+
+func TestParseTransform_errors(test *testing.T) {
+	testCases := []string{
+		`rules: [{}]`,
+		`rules: [{rename: {from: A, to: B}, drop: [C]}]`,
+		`rules: [{rename: {from: A}}]`,
+		`rules: [{concat: {to: A}}]`,
+		`rules: [{nest: {to: NAMES}}]`,
+		`rules: [{split_name: {from: A}}]`,
+		`rules: [{unknown: {from: A, to: B}}]`,
+		`rules: [`,
+	}
+	for _, rules := range testCases {
+		test.Run(rules, func(test *testing.T) {
+			_, err := move.ParseTransform([]byte(rules))
+			require.Error(test, err)
+		})
+	}
+}
+
+func TestBasicMove_Move_missing_transform_file(test *testing.T) {
+	mover := &move.BasicMove{
+		TransformFile: filepath.Join(test.TempDir(), "missing.yaml"),
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func decodeAttributes(t *testing.T, line string) map[string]any {
+	t.Helper()
+
+	var result map[string]any
+
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(&result))
+
+	return result
+}
+
+func writeTransformFile(t *testing.T, rules string) string {
+	t.Helper()
+
+	fileName := filepath.Join(t.TempDir(), "transform.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(rules), 0o600))
+
+	return fileName
+}
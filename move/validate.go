@@ -0,0 +1,383 @@
+package move
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// ValidationResult holds the problems found in a single record.  Errors cause
+// the record to be rejected, warnings are reported but the record is moved.
+type ValidationResult struct {
+	Errors   []string
+	Warnings []string
+}
+
+const (
+	ValidationLevelBasic  = "basic"
+	ValidationLevelStrict = "strict"
+)
+
+// Maximum edit distance for an unknown attribute to be reported as a
+// misspelling of a Generic Entity Specification attribute.
+const maxMisspellingDistance = 2
+
+// Attributes that describe the record itself rather than a feature.
+var recordAttributes = map[string]bool{
+	"DATA_SOURCE": true,
+	"DSRC_ACTION": true,
+	"ENTITY_TYPE": true,
+	"RECORD_ID":   true,
+	"RECORD_TYPE": true,
+}
+
+// Feature attributes of the Senzing Generic Entity Specification.
+// See https://senzing.com/docs/entity_specification/
+var featureAttributes = map[string]bool{
+	"ACCOUNT_DOMAIN":             true,
+	"ACCOUNT_NUMBER":             true,
+	"ADDR_CITY":                  true,
+	"ADDR_COUNTRY":               true,
+	"ADDR_FROM_DATE":             true,
+	"ADDR_FULL":                  true,
+	"ADDR_LINE1":                 true,
+	"ADDR_LINE2":                 true,
+	"ADDR_LINE3":                 true,
+	"ADDR_LINE4":                 true,
+	"ADDR_LINE5":                 true,
+	"ADDR_LINE6":                 true,
+	"ADDR_POSTAL_CODE":           true,
+	"ADDR_STATE":                 true,
+	"ADDR_THRU_DATE":             true,
+	"ADDR_TYPE":                  true,
+	"CITIZENSHIP":                true,
+	"DATE_OF_BIRTH":              true,
+	"DATE_OF_DEATH":              true,
+	"DRIVERS_LICENSE_NUMBER":     true,
+	"DRIVERS_LICENSE_STATE":      true,
+	"DUNS_NUMBER":                true,
+	"EMAIL_ADDRESS":              true,
+	"FACEBOOK":                   true,
+	"GENDER":                     true,
+	"GROUP_ASSN_ID_NUMBER":       true,
+	"GROUP_ASSN_ID_TYPE":         true,
+	"GROUP_ASSOCIATION_ORG_NAME": true,
+	"GROUP_ASSOCIATION_TYPE":     true,
+	"INSTAGRAM":                  true,
+	"LEI_NUMBER":                 true,
+	"LINKEDIN":                   true,
+	"NAME_FIRST":                 true,
+	"NAME_FULL":                  true,
+	"NAME_LAST":                  true,
+	"NAME_MIDDLE":                true,
+	"NAME_ORG":                   true,
+	"NAME_PREFIX":                true,
+	"NAME_SUFFIX":                true,
+	"NAME_TYPE":                  true,
+	"NATIONAL_ID_COUNTRY":        true,
+	"NATIONAL_ID_NUMBER":         true,
+	"NATIONAL_ID_TYPE":           true,
+	"NATIONALITY":                true,
+	"NPI_NUMBER":                 true,
+	"OTHER_ID_COUNTRY":           true,
+	"OTHER_ID_NUMBER":            true,
+	"OTHER_ID_TYPE":              true,
+	"PASSPORT_COUNTRY":           true,
+	"PASSPORT_NUMBER":            true,
+	"PHONE_FROM_DATE":            true,
+	"PHONE_NUMBER":               true,
+	"PHONE_THRU_DATE":            true,
+	"PHONE_TYPE":                 true,
+	"PLACE_OF_BIRTH":             true,
+	"REGISTRATION_COUNTRY":       true,
+	"REGISTRATION_DATE":          true,
+	"REL_ANCHOR_DOMAIN":          true,
+	"REL_ANCHOR_KEY":             true,
+	"REL_POINTER_DOMAIN":         true,
+	"REL_POINTER_FROM_DATE":      true,
+	"REL_POINTER_KEY":            true,
+	"REL_POINTER_ROLE":           true,
+	"REL_POINTER_THRU_DATE":      true,
+	"SIGNAL":                     true,
+	"SKYPE":                      true,
+	"SSN_LAST4":                  true,
+	"SSN_NUMBER":                 true,
+	"TANGO":                      true,
+	"TAX_ID_COUNTRY":             true,
+	"TAX_ID_NUMBER":              true,
+	"TAX_ID_TYPE":                true,
+	"TELEGRAM":                   true,
+	"TRUSTED_ID_NUMBER":          true,
+	"TRUSTED_ID_TYPE":            true,
+	"TWITTER":                    true,
+	"VIBER":                      true,
+	"WEBSITE_ADDRESS":            true,
+	"WECHAT":                     true,
+	"WHATSAPP":                   true,
+	"ZOOMROOM":                   true,
+}
+
+// Conventional feature group names and the attribute prefix their items are
+// expected to use.
+var featureGroupPrefixes = map[string]string{
+	"ADDRESSES": "ADDR_",
+	"NAMES":     "NAME_",
+	"PHONES":    "PHONE_",
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+// ValidateStrict checks a JSON line against the Senzing Generic Entity
+// Specification.  In addition to the checks made by record.Validate, it
+// reports unknown or misspelled attributes, values of the wrong type and
+// malformed feature groups.
+func ValidateStrict(line string) *ValidationResult {
+	result := &ValidationResult{}
+
+	// DATA_SOURCE and RECORD_ID are checked by the basic validation.
+	_, err := record.Validate(line)
+	if err != nil {
+		result.addError("%s", err.Error())
+
+		return result
+	}
+
+	var attributes map[string]any
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(line)))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&attributes)
+	if err != nil {
+		result.addError("JSON-line not well formed: %s", err.Error())
+
+		return result
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		if recordAttributes[name] {
+			continue
+		}
+
+		value := attributes[name]
+
+		if list, isList := value.([]any); isList {
+			result.validateFeatureGroup(name, list)
+
+			continue
+		}
+
+		if _, isObject := value.(map[string]any); isObject {
+			result.addError("%s must be a value or a list of objects", name)
+
+			continue
+		}
+
+		result.validateAttribute("", name, value)
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+// Err returns the errors as a single error, or nil if there are none.
+func (result *ValidationResult) Err() error {
+	if len(result.Errors) == 0 {
+		return nil
+	}
+
+	return wraperror.Errorf(errForPackage, "%s", strings.Join(result.Errors, "; "))
+}
+
+// Valid reports whether the record is free of errors.
+func (result *ValidationResult) Valid() bool {
+	return len(result.Errors) == 0
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+func (result *ValidationResult) addError(format string, details ...any) {
+	result.Errors = append(result.Errors, fmt.Sprintf(format, details...))
+}
+
+func (result *ValidationResult) addWarning(format string, details ...any) {
+	result.Warnings = append(result.Warnings, fmt.Sprintf(format, details...))
+}
+
+// Check the name and type of a single attribute.  prefix locates the
+// attribute for messages, e.g. "NAMES[0].".
+func (result *ValidationResult) validateAttribute(prefix string, name string, value any) {
+	known := isFeatureAttribute(name)
+
+	if !known {
+		suggestion := closestAttribute(name)
+		if suggestion != "" {
+			result.addWarning("%s%s is not a known attribute, did you mean %s", prefix, name, suggestion)
+		} else {
+			result.addWarning("%s%s is not a known attribute and will be treated as payload", prefix, name)
+		}
+	}
+
+	switch value.(type) {
+	case string, json.Number:
+	case nil:
+		result.addWarning("%s%s has no value", prefix, name)
+	default:
+		if known {
+			result.addError("%s%s must be a string or a number", prefix, name)
+		}
+	}
+}
+
+// Check that a list is a well formed feature group: a non-empty list of
+// objects holding scalar attribute values.
+func (result *ValidationResult) validateFeatureGroup(groupName string, list []any) {
+	if len(list) == 0 {
+		result.addWarning("%s is an empty feature group", groupName)
+
+		return
+	}
+
+	expectedPrefix := featureGroupPrefixes[groupName]
+
+	for index, item := range list {
+		prefix := fmt.Sprintf("%s[%d].", groupName, index)
+
+		feature, isObject := item.(map[string]any)
+		if !isObject {
+			result.addError("%s[%d] must be an object in feature group %s", groupName, index, groupName)
+
+			continue
+		}
+
+		if len(feature) == 0 {
+			result.addWarning("%s[%d] is an empty object", groupName, index)
+
+			continue
+		}
+
+		hasExpectedPrefix := false
+
+		for _, name := range slices.Sorted(maps.Keys(feature)) {
+			if recordAttributes[name] {
+				result.addError("%s%s belongs at the top level of the record", prefix, name)
+
+				continue
+			}
+
+			if hasAttributePrefix(name, expectedPrefix) {
+				hasExpectedPrefix = true
+			}
+
+			switch feature[name].(type) {
+			case []any, map[string]any:
+				result.addError("%s%s must be a string or a number", prefix, name)
+
+				continue
+			}
+
+			result.validateAttribute(prefix, name, feature[name])
+		}
+
+		if expectedPrefix != "" && !hasExpectedPrefix {
+			result.addWarning("%s[%d] has no %s* attributes", groupName, index, expectedPrefix)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Return the known attribute closest to name, if it is likely a misspelling.
+func closestAttribute(name string) string {
+	upperName := strings.ToUpper(name)
+	if upperName != name && isFeatureAttribute(upperName) {
+		return upperName
+	}
+
+	result := ""
+	bestDistance := maxMisspellingDistance + 1
+
+	for _, candidate := range slices.Sorted(maps.Keys(featureAttributes)) {
+		distance := editDistance(upperName, candidate)
+		if distance < bestDistance {
+			bestDistance = distance
+			result = candidate
+		}
+	}
+
+	return result
+}
+
+// Levenshtein distance between two ASCII strings.
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// Report whether name starts with prefix, optionally preceded by a usage
+// label such as HOME_ in HOME_ADDR_LINE1 or MAILING_ in MAILING_PHONE_NUMBER.
+func hasAttributePrefix(name string, prefix string) bool {
+	return strings.HasPrefix(name, prefix) || strings.Contains(name, "_"+prefix)
+}
+
+// Report whether name is a feature attribute, optionally preceded by a
+// label such as PRIMARY_ in PRIMARY_NAME_LAST or HOME_ in HOME_ADDR_LINE1.
+func isFeatureAttribute(name string) bool {
+	if featureAttributes[name] {
+		return true
+	}
+
+	for index := strings.Index(name, "_"); index >= 0; {
+		if featureAttributes[name[index+1:]] {
+			return true
+		}
+
+		next := strings.Index(name[index+1:], "_")
+		if next < 0 {
+			break
+		}
+
+		index += next + 1
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+package move_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-queueing/queues"
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test ValidateStrict function
+// ----------------------------------------------------------------------------
+
+func TestValidateStrict(test *testing.T) {
+	testCases := []struct {
+		name             string
+		line             string
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		{
+			name: "valid record",
+			line: `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "NAME_FULL": "Bob Smith", "PRIMARY_NAME_LAST": "Smith", "HOME_ADDR_LINE1": "1 Main St"}`,
+		},
+		{
+			name:           "missing RECORD_ID",
+			line:           `{"DATA_SOURCE": "TEST"}`,
+			expectedErrors: []string{"record.Validate"},
+		},
+		{
+			name:           "not JSON",
+			line:           `{"DATA_SOURCE": "TEST"`,
+			expectedErrors: []string{"record.Validate"},
+		},
+		{
+			name:             "misspelled attribute",
+			line:             `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "NAME_FRST": "Bob"}`,
+			expectedWarnings: []string{"NAME_FRST is not a known attribute, did you mean NAME_FIRST"},
+		},
+		{
+			name:             "lower case attribute",
+			line:             `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "name_last": "Smith"}`,
+			expectedWarnings: []string{"did you mean NAME_LAST"},
+		},
+		{
+			name:             "unknown attribute",
+			line:             `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "CUSTOMER_TIER": "GOLD"}`,
+			expectedWarnings: []string{"CUSTOMER_TIER is not a known attribute and will be treated as payload"},
+		},
+		{
+			name:           "wrong value type",
+			line:           `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "DATE_OF_BIRTH": false, "PHONE_NUMBER": 5551234}`,
+			expectedErrors: []string{"DATE_OF_BIRTH must be a string or a number"},
+		},
+		{
+			name:           "object value",
+			line:           `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "NAME": {"NAME_FULL": "Bob"}}`,
+			expectedErrors: []string{"NAME must be a value or a list of objects"},
+		},
+		{
+			name: "malformed feature groups",
+			line: `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "NAMES": ["Bob", {"NAME_FULL": ["Bob"]}], "ADDRESSES": [{"RECORD_ID": "2"}]}`,
+			expectedErrors: []string{
+				"NAMES[0] must be an object",
+				"NAMES[1].NAME_FULL must be a string or a number",
+				"ADDRESSES[0].RECORD_ID belongs at the top level",
+			},
+			expectedWarnings: []string{"ADDRESSES[0] has no ADDR_* attributes"},
+		},
+		{
+			name: "labeled feature groups",
+			line: `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "ADDRESSES": [{"HOME_ADDR_LINE1": "1 Main St"}], "PHONES": [{"MAILING_PHONE_NUMBER": "555-1234"}]}`,
+		},
+		{
+			name: "feature group warnings",
+			line: `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "NAMES": [{"ADDR_FULL": "1 Main St"}, {}], "PHONES": []}`,
+			expectedWarnings: []string{
+				"NAMES[0] has no NAME_* attributes",
+				"NAMES[1] is an empty object",
+				"PHONES is an empty feature group",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			result := move.ValidateStrict(testCase.line)
+			require.Len(test, result.Errors, len(testCase.expectedErrors), result.Errors)
+			require.Len(test, result.Warnings, len(testCase.expectedWarnings), result.Warnings)
+			require.Equal(test, len(testCase.expectedErrors) == 0, result.Valid())
+
+			for _, expected := range testCase.expectedErrors {
+				require.Contains(test, strings.Join(result.Errors, "\n"), expected)
+			}
+
+			for _, expected := range testCase.expectedWarnings {
+				require.Contains(test, strings.Join(result.Warnings, "\n"), expected)
+			}
+
+			if result.Valid() {
+				require.NoError(test, result.Err())
+			} else {
+				require.Error(test, result.Err())
+			}
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// test strict validation while processing
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_processJSONL_strict(test *testing.T) {
+	reader, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	input := `{"DATA_SOURCE": "TEST", "RECORD_ID": "1", "NAME_FULL": "Bob Smith"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "2", "NAME_FRST": "Bob"}
+{"DATA_SOURCE": "TEST", "RECORD_ID": "3", "NAMES": ["Bob"]}
+`
+	recordchan := make(chan queues.Record, 5)
+
+	mover := &move.BasicMove{
+		ValidationLevel: move.ValidationLevelStrict,
+	}
+	mover.ProcessJSONL("test", strings.NewReader(input), recordchan)
+
+	writer.Close()
+
+	actual := 0
+	for range recordchan {
+		actual++
+	}
+
+	require.Equal(test, 2, actual)
+
+	out, err := io.ReadAll(reader)
+	require.NoError(test, err)
+	require.Contains(test, string(out), "Warning validating line 2")
+	require.Contains(test, string(out), "Error validating line 3")
+}
+
+func TestBasicMove_Move_unknown_validation_level(test *testing.T) {
+	mover := &move.BasicMove{
+		ValidationLevel: "bad",
+	}
+
+	err := mover.Move(test.Context())
+	require.Error(test, err)
+}
@@ -0,0 +1,464 @@
+package move
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-queueing/queues"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// WatchManifestEntry records the outcome of moving one watched file.
+type WatchManifestEntry struct {
+	Error string `json:"error,omitempty"`
+	File  string `json:"file"`
+	// The name the file was filed under, when one of the same name had
+	// already been filed.
+	FiledAs string `json:"filed_as,omitempty"`
+	Lines   int    `json:"lines"`
+	// The records the writer acknowledged.
+	Moved  int       `json:"moved"`
+	Status string    `json:"status"`
+	Time   time.Time `json:"time"`
+}
+
+const (
+	WatchFailed    = "failed"
+	WatchProcessed = "processed"
+)
+
+// A file being written in the watch directory, moved once it has settled.
+type watchPending struct {
+	modTime time.Time
+	order   int
+	since   time.Time
+	size    int64
+}
+
+const (
+	defaultWatchManifest = "manifest.jsonl"
+	watchMarkerSuffix    = ".done"
+	// How long a file must keep its size and modification time to be
+	// considered complete.
+	watchSettleTime = time.Second
+)
+
+// ----------------------------------------------------------------------------
+// -- Public methods
+// ----------------------------------------------------------------------------
+
+// Watch monitors WatchDirectory and moves each completed file through the
+// configured pipeline to OutputURL, until ctx is cancelled.  A file is
+// complete when its size and modification time have not changed for a
+// second or, with WatchRequireMarker, when a marker file of the same name
+// ending in .done is created.  Files already in the directory are moved when
+// Watch starts.  Once the writer has acknowledged its records, a moved file
+// goes to the processed/ or failed/ subdirectory and the outcome is appended
+// to the WatchManifest file.
+func (move *BasicMove) Watch(ctx context.Context) error {
+	var (
+		waitGroup sync.WaitGroup
+		writeErr  error
+	)
+
+	err := move.validateOptions()
+	if err != nil {
+		return err
+	}
+
+	err = move.prepareWatchDirectory()
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return wraperror.Errorf(err, "fsnotify.NewWatcher")
+	}
+
+	defer watcher.Close()
+
+	err = watcher.Add(move.WatchDirectory)
+	if err != nil {
+		return wraperror.Errorf(err, "watcher.Add %s", move.WatchDirectory)
+	}
+
+	move.logBuildInfo()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	recordchan := make(chan queues.Record, numChannels)
+
+	waitGroup.Add(1)
+
+	go func() {
+		defer waitGroup.Done()
+
+		// Without a writer there is no point in reading.  Its error fails the
+		// files whose records it has not acknowledged.
+		writeErr = move.write(ctx, recordchan)
+		cancel(writeErr)
+
+		if writeErr != nil {
+			discardRecords(recordchan)
+		}
+	}()
+
+	ticker := time.NewTicker(watchSettleTime / 4) //nolint:mnd
+	defer ticker.Stop()
+
+	pending := map[string]*watchPending{}
+
+	err = move.watchExisting(ctx, recordchan, pending)
+
+	for err == nil && ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+		case event := <-watcher.Events:
+			if event.Has(fsnotify.Create) || event.Has(fsnotify.Write) {
+				err = move.watchChanged(ctx, event.Name, recordchan, pending)
+			}
+		case <-ticker.C:
+			err = move.watchSettled(ctx, recordchan, pending)
+		case watchErr := <-watcher.Errors:
+			move.log(3016, move.WatchDirectory, watchErr)
+		}
+	}
+
+	close(recordchan)
+	waitGroup.Wait()
+
+	closeErr := move.closeDedupe()
+
+	switch {
+	case err != nil:
+		return err
+	case writeErr != nil:
+		return writeErr
+	default:
+		return closeErr
+	}
+}
+
+// ----------------------------------------------------------------------------
+// -- Private methods
+// ----------------------------------------------------------------------------
+
+// Check the watch directory and create its processed/ and failed/
+// subdirectories.
+func (move *BasicMove) prepareWatchDirectory() error {
+	info, err := os.Stat(move.WatchDirectory)
+	if err != nil {
+		return wraperror.Errorf(err, "os.Stat")
+	}
+
+	if !info.IsDir() {
+		return wraperror.Errorf(errForPackage, "not a directory: %s", move.WatchDirectory)
+	}
+
+	for _, subdirectory := range []string{WatchFailed, WatchProcessed} {
+		err = os.MkdirAll(filepath.Join(move.WatchDirectory, subdirectory), 0o750) //nolint:mnd
+		if err != nil {
+			return wraperror.Errorf(err, "os.MkdirAll")
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Move the files already in the watch directory once they have settled, or
+// those whose marker is there.
+func (move *BasicMove) watchExisting(
+	ctx context.Context,
+	recordchan chan queues.Record,
+	pending map[string]*watchPending,
+) error {
+	entries, err := os.ReadDir(move.WatchDirectory)
+	if err != nil {
+		return wraperror.Errorf(err, "os.ReadDir")
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	for _, name := range slices.Sorted(slices.Values(names)) {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		err = move.watchChanged(ctx, filepath.Join(move.WatchDirectory, name), recordchan, pending)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Handle a file created or written in the watch directory.  The data file of
+// a marker is moved at once; other files are moved once they settle.
+func (move *BasicMove) watchChanged(
+	ctx context.Context,
+	path string,
+	recordchan chan queues.Record,
+	pending map[string]*watchPending,
+) error {
+	name := filepath.Base(path)
+
+	if strings.HasSuffix(name, watchMarkerSuffix) {
+		dataFile := strings.TrimSuffix(path, watchMarkerSuffix)
+		if !move.isWatchedFile(dataFile) {
+			return nil
+		}
+
+		delete(pending, dataFile)
+
+		err := move.watchFile(ctx, dataFile, recordchan)
+		if err != nil || ctx.Err() != nil {
+			return err
+		}
+
+		return wraperror.Errorf(os.Remove(path), "os.Remove")
+	}
+
+	if move.WatchRequireMarker || !move.isWatchedFile(path) {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+
+	file, isPending := pending[path]
+	if !isPending {
+		file = &watchPending{}
+		for _, other := range pending {
+			file.order = max(file.order, other.order+1)
+		}
+
+		pending[path] = file
+	}
+
+	file.modTime = info.ModTime()
+	file.since = time.Now()
+	file.size = info.Size()
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Move the pending files that have settled, in the order they were found.
+// A file that changed since it was last seen must settle again.
+func (move *BasicMove) watchSettled(
+	ctx context.Context,
+	recordchan chan queues.Record,
+	pending map[string]*watchPending,
+) error {
+	paths := slices.SortedFunc(maps.Keys(pending), func(a, b string) int {
+		return pending[a].order - pending[b].order
+	})
+
+	for _, path := range paths {
+		file := pending[path]
+
+		info, err := os.Stat(path)
+		if err != nil {
+			delete(pending, path)
+
+			continue
+		}
+
+		if info.Size() != file.size || !info.ModTime().Equal(file.modTime) {
+			file.modTime = info.ModTime()
+			file.since = time.Now()
+			file.size = info.Size()
+
+			continue
+		}
+
+		if time.Since(file.since) < watchSettleTime {
+			continue
+		}
+
+		delete(pending, path)
+
+		err = move.watchFile(ctx, path, recordchan)
+		if err != nil || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Report whether path is a regular file that should be moved.  Hidden,
+// temporary and marker files and the manifest are skipped.
+func (move *BasicMove) isWatchedFile(path string) bool {
+	name := filepath.Base(path)
+	format := strings.TrimSuffix(name, ageSuffix)
+
+	switch {
+	case strings.HasPrefix(name, "."),
+		strings.HasSuffix(name, watchMarkerSuffix),
+		strings.HasSuffix(name, ".tmp"),
+		strings.HasSuffix(name, ".part"),
+		filepath.Clean(path) == filepath.Clean(move.watchManifest()):
+		return false
+	case move.FileType == "" && !strings.HasSuffix(format, "jsonl") && !strings.HasSuffix(format, "gz"):
+		return false
+	}
+
+	info, err := os.Stat(path)
+
+	return err == nil && info.Mode().IsRegular()
+}
+
+// ----------------------------------------------------------------------------
+
+// Move a single file and, once the writer has acknowledged its records, file
+// it under processed/ or failed/.
+func (move *BasicMove) watchFile(ctx context.Context, path string, recordchan chan queues.Record) error {
+	result := &InputResult{
+		delivery: newDelivery(),
+		Input:    fileURL(path),
+	}
+
+	entry := &WatchManifestEntry{
+		File:   filepath.Base(path),
+		Status: WatchProcessed,
+	}
+
+	result.Err = move.readInput(ctx, result.Input, recordchan, result)
+	result.delivery.seal()
+
+	delivered, err := result.delivery.wait(ctx)
+
+	// A file interrupted by shutdown is left in place to be moved again; one
+	// whose records the writer failed to deliver is failed.
+	if isStopped(ctx) {
+		return nil
+	}
+
+	if result.Err == nil {
+		result.Err = err
+	}
+
+	if result.Err != nil {
+		move.log(3015, result.Input, result.Err)
+
+		entry.Error = RedactError(result.Err).Error()
+		entry.Status = WatchFailed
+	}
+
+	move.log(2007, result.Input, result.Lines, delivered)
+
+	filed, err := fileWatched(path, filepath.Join(move.WatchDirectory, entry.Status))
+	if err != nil {
+		return err
+	}
+
+	if filed != entry.File {
+		entry.FiledAs = filed
+	}
+
+	entry.Lines = result.Lines
+	entry.Moved = delivered
+	entry.Time = time.Now().UTC()
+
+	return move.writeWatchManifest(entry)
+}
+
+// ----------------------------------------------------------------------------
+
+func (move *BasicMove) watchManifest() string {
+	if move.WatchManifest != "" {
+		return move.WatchManifest
+	}
+
+	return filepath.Join(move.WatchDirectory, defaultWatchManifest)
+}
+
+// ----------------------------------------------------------------------------
+
+// Append an entry to the manifest as a JSON line.
+func (move *BasicMove) writeWatchManifest(entry *WatchManifestEntry) error {
+	file, err := os.OpenFile(
+		filepath.Clean(move.watchManifest()),
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		0o600, //nolint:mnd
+	)
+	if err != nil {
+		return wraperror.Errorf(err, "os.OpenFile")
+	}
+
+	err = json.NewEncoder(file).Encode(entry)
+	if err != nil {
+		_ = file.Close()
+
+		return wraperror.Errorf(err, "json.Encode")
+	}
+
+	return wraperror.Errorf(file.Close(), "file.Close")
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Move a watched file into a directory and return the name it was given.  A
+// file of the same name filed earlier is kept by numbering the new one, e.g.
+// vendor-2.jsonl.
+func fileWatched(path string, directory string) (string, error) {
+	name := filepath.Base(path)
+	stem, extension := name, ""
+
+	if index := strings.Index(name, "."); index > 0 {
+		stem, extension = name[:index], name[index:]
+	}
+
+	for count := 2; ; count++ {
+		_, err := os.Lstat(filepath.Join(directory, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+
+		if err != nil {
+			return "", wraperror.Errorf(err, "os.Lstat")
+		}
+
+		name = fmt.Sprintf("%s-%d%s", stem, count, extension)
+	}
+
+	err := os.Rename(path, filepath.Join(directory, name))
+	if err != nil {
+		return "", wraperror.Errorf(err, "os.Rename")
+	}
+
+	return name, nil
+}
@@ -0,0 +1,314 @@
+//go:build !windows
+
+package move_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/move/move"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// test watching a directory
+// ----------------------------------------------------------------------------
+
+func TestBasicMove_Watch(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	watchDir := test.TempDir()
+	stagingDir := test.TempDir()
+	outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+	// Already in the directory when watching starts.
+	writeFile(test, filepath.Join(watchDir, "existing.jsonl"), testGoodData)
+
+	ctx, cancel := context.WithCancel(test.Context())
+	done := make(chan error)
+
+	mover := &move.BasicMove{
+		OutputURL:      "file://" + outputFile,
+		WatchDirectory: watchDir,
+	}
+
+	go func() {
+		done <- mover.Watch(ctx)
+	}()
+
+	waitForManifest(test, watchDir, 1)
+
+	// Files renamed into the directory.
+	writeFile(test, filepath.Join(stagingDir, "new.jsonl"), testGoodData)
+	require.NoError(test, os.Rename(filepath.Join(stagingDir, "new.jsonl"), filepath.Join(watchDir, "new.jsonl")))
+
+	writeFile(test, filepath.Join(stagingDir, "broken.gz"), "not gzip")
+	require.NoError(test, os.Rename(filepath.Join(stagingDir, "broken.gz"), filepath.Join(watchDir, "broken.gz")))
+
+	// Files that are skipped.
+	writeFile(test, filepath.Join(watchDir, "notes.txt"), testGoodData)
+	writeFile(test, filepath.Join(watchDir, "partial.jsonl.tmp"), testGoodData)
+
+	entries := waitForManifest(test, watchDir, 3)
+
+	cancel()
+	require.NoError(test, <-done)
+	writer.Close()
+
+	require.Equal(test, "existing.jsonl", entries[0].File)
+	require.Equal(test, move.WatchProcessed, entries[0].Status)
+	require.Equal(test, 12, entries[0].Lines)
+	require.Equal(test, "new.jsonl", entries[1].File)
+	require.Equal(test, move.WatchProcessed, entries[1].Status)
+	require.Equal(test, "broken.gz", entries[2].File)
+	require.Equal(test, move.WatchFailed, entries[2].Status)
+	require.NotEmpty(test, entries[2].Error)
+
+	require.FileExists(test, filepath.Join(watchDir, "processed", "existing.jsonl"))
+	require.FileExists(test, filepath.Join(watchDir, "processed", "new.jsonl"))
+	require.FileExists(test, filepath.Join(watchDir, "failed", "broken.gz"))
+	require.FileExists(test, filepath.Join(watchDir, "notes.txt"))
+	require.FileExists(test, filepath.Join(watchDir, "partial.jsonl.tmp"))
+	require.Equal(test, 2*entries[0].Moved, countLines(test, outputFile))
+}
+
+// A file of the same name as one filed earlier does not replace it.
+func TestBasicMove_Watch_same_name(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	watchDir := test.TempDir()
+	stagingDir := test.TempDir()
+	outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+	ctx, cancel := context.WithCancel(test.Context())
+	done := make(chan error)
+
+	mover := &move.BasicMove{
+		OutputURL:      "file://" + outputFile,
+		WatchDirectory: watchDir,
+	}
+
+	go func() {
+		done <- mover.Watch(ctx)
+	}()
+
+	for count := 1; count <= 3; count++ {
+		writeFile(test, filepath.Join(stagingDir, "vendor.jsonl.gz"), "not gzip")
+		require.NoError(test, os.Rename(
+			filepath.Join(stagingDir, "vendor.jsonl.gz"),
+			filepath.Join(watchDir, "vendor.jsonl.gz"),
+		))
+
+		waitForManifest(test, watchDir, count)
+	}
+
+	entries := waitForManifest(test, watchDir, 3)
+
+	cancel()
+	require.NoError(test, <-done)
+	writer.Close()
+
+	require.Equal(test, "vendor.jsonl.gz", entries[0].File)
+	require.Empty(test, entries[0].FiledAs)
+	require.Equal(test, "vendor-2.jsonl.gz", entries[1].FiledAs)
+	require.Equal(test, "vendor-3.jsonl.gz", entries[2].FiledAs)
+
+	for _, name := range []string{"vendor.jsonl.gz", "vendor-2.jsonl.gz", "vendor-3.jsonl.gz"} {
+		require.FileExists(test, filepath.Join(watchDir, "failed", name))
+	}
+}
+
+func TestBasicMove_Watch_require_marker(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	watchDir := test.TempDir()
+	manifest := filepath.Join(test.TempDir(), "manifest.jsonl")
+	outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+	ctx, cancel := context.WithCancel(test.Context())
+	done := make(chan error)
+
+	mover := &move.BasicMove{
+		OutputURL:          "file://" + outputFile,
+		WatchDirectory:     watchDir,
+		WatchManifest:      manifest,
+		WatchRequireMarker: true,
+	}
+
+	go func() {
+		done <- mover.Watch(ctx)
+	}()
+
+	// Give the watcher time to start, then write the data file in place.
+	time.Sleep(100 * time.Millisecond)
+	writeFile(test, filepath.Join(watchDir, "data.jsonl"), testGoodData)
+	time.Sleep(100 * time.Millisecond)
+	require.FileExists(test, filepath.Join(watchDir, "data.jsonl"))
+
+	writeFile(test, filepath.Join(watchDir, "data.jsonl.done"), "")
+
+	entries := waitForEntries(test, manifest, 1)
+
+	cancel()
+	require.NoError(test, <-done)
+	writer.Close()
+
+	require.Equal(test, "data.jsonl", entries[0].File)
+	require.Equal(test, move.WatchProcessed, entries[0].Status)
+	require.NoFileExists(test, filepath.Join(watchDir, "data.jsonl.done"))
+	require.FileExists(test, filepath.Join(watchDir, "processed", "data.jsonl"))
+}
+
+// A file written in place is moved once it has stopped changing.
+func TestBasicMove_Watch_written_in_place(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	watchDir := test.TempDir()
+	outputFile := filepath.Join(test.TempDir(), "output.jsonl")
+
+	ctx, cancel := context.WithCancel(test.Context())
+	done := make(chan error)
+
+	mover := &move.BasicMove{
+		OutputURL:      "file://" + outputFile,
+		WatchDirectory: watchDir,
+	}
+
+	go func() {
+		done <- mover.Watch(ctx)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	lines := strings.SplitAfter(testGoodData, "\n")
+	file, err := os.Create(filepath.Join(watchDir, "slow.jsonl"))
+	require.NoError(test, err)
+
+	for _, line := range lines {
+		_, err = file.WriteString(line)
+		require.NoError(test, err)
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	require.NoError(test, file.Close())
+
+	entries := waitForManifest(test, watchDir, 1)
+
+	cancel()
+	require.NoError(test, <-done)
+	writer.Close()
+
+	require.Len(test, entries, 1)
+	require.Equal(test, move.WatchProcessed, entries[0].Status)
+	require.Equal(test, 12, entries[0].Lines)
+	require.Equal(test, 12, entries[0].Moved)
+	require.Equal(test, 12, countLines(test, outputFile))
+}
+
+// A file whose records the writer fails to deliver is failed.
+func TestBasicMove_Watch_writer_fails(test *testing.T) {
+	_, writer, cleanUp := mockStdout(test)
+	defer cleanUp()
+
+	queue := startFakeSQS(test)
+	queue.rejectAll = true
+	queue.senderFault = true
+
+	watchDir := test.TempDir()
+	writeFile(test, filepath.Join(watchDir, "records.jsonl"), testGoodData)
+
+	mover := &move.BasicMove{
+		OutputURL:      "sqs://lookup?queue-name=records",
+		WatchDirectory: watchDir,
+	}
+
+	err := mover.Watch(test.Context())
+	writer.Close()
+	require.Error(test, err)
+
+	entries := waitForManifest(test, watchDir, 1)
+	require.Equal(test, move.WatchFailed, entries[0].Status)
+	require.Equal(test, 0, entries[0].Moved)
+	require.NotEmpty(test, entries[0].Error)
+	require.FileExists(test, filepath.Join(watchDir, "failed", "records.jsonl"))
+}
+
+// Encrypted files are complete only once closed.
+func TestBasicMove_Watch_encrypted_output(test *testing.T) {
+	identity, _ := createAgeIdentity(test, test.TempDir())
+
+	mover := &move.BasicMove{
+		AgeRecipients:  []string{identity.Recipient().String()},
+		OutputURL:      "file://" + filepath.Join(test.TempDir(), "output.jsonl.age"),
+		WatchDirectory: test.TempDir(),
+	}
+
+	err := mover.Watch(test.Context())
+	require.ErrorContains(test, err, "encrypted outputs cannot be written while")
+}
+
+func TestBasicMove_Watch_not_a_directory(test *testing.T) {
+	mover := &move.BasicMove{
+		WatchDirectory: filepath.Join(test.TempDir(), "missing"),
+	}
+
+	err := mover.Watch(test.Context())
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func writeFile(t *testing.T, fileName string, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0o600))
+}
+
+func waitForManifest(t *testing.T, watchDir string, count int) []move.WatchManifestEntry {
+	t.Helper()
+
+	return waitForEntries(t, filepath.Join(watchDir, "manifest.jsonl"), count)
+}
+
+// Wait for the manifest to have count entries.
+func waitForEntries(t *testing.T, manifest string, count int) []move.WatchManifestEntry {
+	t.Helper()
+
+	var result []move.WatchManifestEntry
+
+	require.Eventually(t, func() bool {
+		result = nil
+
+		file, err := os.Open(manifest)
+		if err != nil {
+			return false
+		}
+
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var entry move.WatchManifestEntry
+
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+
+			result = append(result, entry)
+		}
+
+		return len(result) >= count
+	}, 10*time.Second, 20*time.Millisecond)
+
+	return result
+}